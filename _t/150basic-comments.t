@@ -0,0 +1,72 @@
+#!/bin/bash
+eval "$(curl -q -s https://raw.githubusercontent.com/coryb/osht/master/osht.sh)"
+cd $(dirname $0)
+jira="../jira"
+. env.sh
+
+PLAN 18
+
+# reset login
+RUNS $jira logout
+RUNS $jira login
+
+# cleanup from previous failed test executions
+($jira ls --project BASIC | awk -F: '{print $1}' | while read issue; do ../jira done $issue; done) | sed 's/^/# CLEANUP: /g'
+
+###############################################################################
+## Create an issue
+###############################################################################
+RUNS $jira create --project BASIC -o summary=summary -o description=description --noedit --saveFile issue.props
+issue=$(awk '/issue/{print $2}' issue.props)
+
+DIFF <<EOF
+OK $issue $ENDPOINT/browse/$issue
+EOF
+
+###############################################################################
+## Add a comment with the old command name and with comment add
+###############################################################################
+RUNS $jira comment $issue --noedit -m "first comment"
+DIFF <<EOF
+OK $issue $ENDPOINT/browse/$issue
+EOF
+
+RUNS $jira comment add $issue --noedit -m "second comment"
+DIFF <<EOF
+OK $issue $ENDPOINT/browse/$issue
+EOF
+
+###############################################################################
+## List the comments
+###############################################################################
+RUNS $jira comment list $issue --gjq 'comments.#.body'
+DIFF <<EOF
+["first comment","second comment"]
+EOF
+comment=$($jira comment list $issue --gjq 'comments.0.id')
+
+###############################################################################
+## Edit the first comment
+###############################################################################
+RUNS $jira comment edit $issue $comment --noedit -m "edited comment"
+DIFF <<EOF
+OK $issue $ENDPOINT/browse/$issue
+EOF
+
+RUNS $jira comment list $issue --gjq 'comments.#.body'
+DIFF <<EOF
+["edited comment","second comment"]
+EOF
+
+###############################################################################
+## Delete the first comment
+###############################################################################
+RUNS $jira comment delete $issue $comment
+DIFF <<EOF
+OK Deleted Comment $comment from $issue
+EOF
+
+RUNS $jira comment list $issue --gjq 'comments.#.body'
+DIFF <<EOF
+["second comment"]
+EOF
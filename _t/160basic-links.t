@@ -0,0 +1,63 @@
+#!/bin/bash
+eval "$(curl -q -s https://raw.githubusercontent.com/coryb/osht/master/osht.sh)"
+cd $(dirname $0)
+jira="../jira"
+. env.sh
+
+PLAN 16
+
+# reset login
+RUNS $jira logout
+RUNS $jira login
+
+# cleanup from previous failed test executions
+($jira ls --project BASIC | awk -F: '{print $1}' | while read issue; do ../jira done $issue; done) | sed 's/^/# CLEANUP: /g'
+
+###############################################################################
+## Create two issues and block one with the other
+###############################################################################
+RUNS $jira create --project BASIC -o summary=summary -o description=description --noedit --saveFile issue.props
+issue=$(awk '/issue/{print $2}' issue.props)
+
+DIFF <<EOF
+OK $issue $ENDPOINT/browse/$issue
+EOF
+
+RUNS $jira create --project BASIC -o summary=blocks -o description=blocks --noedit --saveFile issue.props
+blocker=$(awk '/issue/{print $2}' issue.props)
+
+DIFF <<EOF
+OK $blocker $ENDPOINT/browse/$blocker
+EOF
+
+RUNS $jira block $blocker $issue
+DIFF <<EOF
+OK $issue $ENDPOINT/browse/$issue
+OK $blocker $ENDPOINT/browse/$blocker
+EOF
+
+###############################################################################
+## List the links of the issue
+###############################################################################
+RUNS $jira link list $issue --gjq 'groups.0.type'
+DIFF <<EOF
+Blocks
+EOF
+
+RUNS $jira link list $issue --gjq 'groups.0.issues.#.key'
+DIFF <<EOF
+["$blocker"]
+EOF
+
+###############################################################################
+## Remove the link, after which there are no links left
+###############################################################################
+RUNS $jira link remove $issue $blocker
+DIFF <<EOF
+OK $issue $ENDPOINT/browse/$issue
+OK $blocker $ENDPOINT/browse/$blocker
+EOF
+
+RUNS $jira link list $issue
+DIFF <<EOF
+EOF
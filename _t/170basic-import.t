@@ -0,0 +1,67 @@
+#!/bin/bash
+eval "$(curl -q -s https://raw.githubusercontent.com/coryb/osht/master/osht.sh)"
+cd $(dirname $0)
+jira="../jira"
+. env.sh
+
+PLAN 12
+
+# reset login
+RUNS $jira logout
+RUNS $jira login
+
+# cleanup from previous failed test executions
+($jira ls --project BASIC | awk -F: '{print $1}' | while read issue; do ../jira done $issue; done) | sed 's/^/# CLEANUP: /g'
+rm -f import.yml import.yml.state
+
+cat > .jira.d/templates/import-status <<EOF
+{{ range .issues }}{{ .row }} {{ .ref }} {{ .status }} {{ .summary }}
+{{ end -}}
+EOF
+
+cat > import.yml <<EOF
+ref: story
+summary: imported story
+description: imported description
+---
+summary: imported task
+issuetype: Bug
+links:
+  - type: Blocks
+    outward: story
+EOF
+
+###############################################################################
+## Check the rows without creating anything
+###############################################################################
+RUNS $jira import --project BASIC --dryrun -t import-status import.yml
+DIFF <<EOF
+1 story valid imported story
+2  valid imported task
+EOF
+
+RUNS $jira ls --project BASIC
+DIFF <<EOF
+EOF
+
+###############################################################################
+## Import the issues, then run the import again which finds them in the state
+###############################################################################
+RUNS $jira import --project BASIC -t import-status import.yml
+DIFF <<EOF
+1 story created imported story
+2  created imported task
+EOF
+
+RUNS $jira import --project BASIC -t import-status import.yml
+DIFF <<EOF
+1 story exists imported story
+2  exists imported task
+EOF
+
+RUNS $jira ls --project BASIC --gjq 'issues.#.fields.summary'
+DIFF <<EOF
+["imported story","imported task"]
+EOF
+
+rm -f import.yml import.yml.state .jira.d/templates/import-status
@@ -0,0 +1,73 @@
+package jira
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+)
+
+// Version 3 of the REST API is only available on Jira Cloud, it is the same
+// as version 2 except that rich text fields, like descriptions and comment
+// bodies, are Atlassian Document Format documents rather than wiki markup.
+// Only the requests for issues, comments, transitions and searches are sent
+// to version 3, where the ADF documents are found in the fields of the issues.
+// The jiradata types with rich text strings, like Comment, cannot decode ADF
+// documents so the responses have to be converted first, as the jira command
+// does.
+var apiVersionPattern = regexp.MustCompile(`^(.*/rest/api/)2(/(?:issue(?:/[^/]+(?:/comment(?:/[^/]+)?|/transitions)?)?|search))$`)
+
+// SetAPIVersion changes the URL of a request for issues, comments,
+// transitions or searches to the version of the REST API, the URL is left
+// unchanged for other requests or when the version is empty or "2".
+func SetAPIVersion(u *url.URL, version string) {
+	if version == "" || version == "2" || !apiVersionPattern.MatchString(u.Path) {
+		return
+	}
+	u.Path = apiVersionPattern.ReplaceAllString(u.Path, "${1}"+version+"${2}")
+	u.RawPath = ""
+}
+
+type apiVersionClient struct {
+	ua      HttpClient
+	version string
+}
+
+// WithAPIVersion returns a client sending the requests for issues, comments,
+// transitions and searches to the version of the REST API, like:
+//
+//	j := jira.NewJira(endpoint)
+//	j.UA = jira.WithAPIVersion(j.UA, "3")
+func WithAPIVersion(ua HttpClient, version string) HttpClient {
+	return &apiVersionClient{ua: ua, version: version}
+}
+
+func (c *apiVersionClient) url(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return uri
+	}
+	SetAPIVersion(u, c.version)
+	return u.String()
+}
+
+func (c *apiVersionClient) Delete(uri string) (*http.Response, error) {
+	return c.ua.Delete(c.url(uri))
+}
+
+func (c *apiVersionClient) Do(req *http.Request) (*http.Response, error) {
+	SetAPIVersion(req.URL, c.version)
+	return c.ua.Do(req)
+}
+
+func (c *apiVersionClient) GetJSON(uri string) (*http.Response, error) {
+	return c.ua.GetJSON(c.url(uri))
+}
+
+func (c *apiVersionClient) Post(uri, bodyType string, body io.Reader) (*http.Response, error) {
+	return c.ua.Post(c.url(uri), bodyType, body)
+}
+
+func (c *apiVersionClient) Put(uri, bodyType string, body io.Reader) (*http.Response, error) {
+	return c.ua.Put(c.url(uri), bodyType, body)
+}
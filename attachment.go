@@ -2,6 +2,9 @@ package jira
 
 import (
 	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
 
 	"github.com/go-jira/jira/jiradata"
 )
@@ -26,6 +29,36 @@ func GetAttachment(ua HttpClient, endpoint string, id string) (*jiradata.Attachm
 	return nil, responseError(resp)
 }
 
+func (j *Jira) GetAttachmentContent(attachment *jiradata.Attachment) (io.ReadCloser, error) {
+	return GetAttachmentContent(j.UA, attachment)
+}
+
+// GetAttachmentContent downloads the content of the attachment, the caller
+// must close the returned reader.
+func GetAttachmentContent(ua HttpClient, attachment *jiradata.Attachment) (io.ReadCloser, error) {
+	req, err := http.NewRequest("GET", attachment.Content, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ua.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == 200 {
+		return resp.Body, nil
+	}
+	defer resp.Body.Close()
+	if !strings.Contains(resp.Header.Get("Content-Type"), "json") {
+		// downloads usually fail with an html error page
+		return nil, &jiradata.ErrorCollection{
+			Status:        resp.StatusCode,
+			ErrorMessages: []string{resp.Status},
+		}
+	}
+	return nil, responseError(resp)
+}
+
 // https://docs.atlassian.com/jira/REST/cloud/#api/2/attachment-removeAttachment
 func (j *Jira) RemoveAttachment(id string) error {
 	return RemoveAttachment(j.UA, j.Endpoint, id)
@@ -13,12 +13,51 @@ import (
 )
 
 // https://docs.atlassian.com/jira-software/REST/latest/#agile/1.0/epic-getIssuesForEpic
-func (j *Jira) EpicSearch(epic string, sp SearchProvider) (*jiradata.SearchResults, error) {
-	return EpicSearch(j.UA, j.Endpoint, epic, sp)
+func (j *Jira) EpicSearch(epic string, sp SearchProvider, opts ...SearchOpt) (*jiradata.SearchResults, error) {
+	return EpicSearch(j.UA, j.Endpoint, epic, sp, opts...)
 }
 
-func EpicSearch(ua HttpClient, endpoint string, epic string, sp SearchProvider) (*jiradata.SearchResults, error) {
+func EpicSearch(ua HttpClient, endpoint string, epic string, sp SearchProvider, opts ...SearchOpt) (*jiradata.SearchResults, error) {
+	c := &searchConfig{}
+	for _, opt := range opts {
+		opt(c)
+	}
+
 	req := sp.ProvideSearchRequest()
+	limit := req.MaxResults
+	issues := jiradata.Issues{}
+	fetched := 0
+	for {
+		page, err := epicSearchPage(ua, endpoint, epic, req)
+		if err != nil {
+			return nil, err
+		}
+		if !c.autoPaginate {
+			return page, nil
+		}
+
+		fetched += len(page.Issues)
+		if c.pageHandler != nil {
+			if err := c.pageHandler(page.Issues); err != nil {
+				return nil, err
+			}
+		} else {
+			issues = append(issues, page.Issues...)
+		}
+		// the page size is limited by the server, so keep fetching until
+		// all the issues of the epic are returned
+		if (limit > 0 && fetched >= limit) || fetched >= page.Total || len(page.Issues) == 0 {
+			page.Issues = issues
+			return page, nil
+		}
+		req.StartAt = fetched
+		if limit > 0 && fetched+req.MaxResults > limit {
+			req.MaxResults = limit - fetched
+		}
+	}
+}
+
+func epicSearchPage(ua HttpClient, endpoint string, epic string, req *jiradata.SearchRequest) (*jiradata.SearchResults, error) {
 	// encoded, err := json.Marshal(req)
 	// if err != nil {
 	// 	return nil, err
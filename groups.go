@@ -0,0 +1,103 @@
+package jira
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/go-jira/jira/jiradata"
+)
+
+// https://developer.atlassian.com/cloud/jira/platform/rest/v2/#api-rest-api-2-group-member-get
+func (j *Jira) GroupMembers(group string, includeInactive bool) (jiradata.Users, error) {
+	return GroupMembers(j.UA, j.Endpoint, group, includeInactive)
+}
+
+// GroupMembers returns all the members of the group, fetching every page of
+// results.
+func GroupMembers(ua HttpClient, endpoint string, group string, includeInactive bool) (jiradata.Users, error) {
+	startAt := 0
+	users := jiradata.Users{}
+	for {
+		uri := URLJoin(endpoint, "rest/api/2/group/member")
+		uri += fmt.Sprintf("?groupname=%s&startAt=%d", url.QueryEscape(group), startAt)
+		if includeInactive {
+			uri += "&includeInactiveUsers=true"
+		}
+		resp, err := ua.GetJSON(uri)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != 200 {
+			return nil, responseError(resp)
+		}
+		results := &jiradata.UsersWithPagination{}
+		if err := json.NewDecoder(resp.Body).Decode(results); err != nil {
+			return nil, err
+		}
+		users = append(users, results.Values...)
+		if results.IsLast || len(results.Values) == 0 {
+			return users, nil
+		}
+		startAt += len(results.Values)
+	}
+}
+
+// https://developer.atlassian.com/cloud/jira/platform/rest/v2/#api-rest-api-2-group-user-post
+func (j *Jira) GroupAddUser(group string, user *jiradata.User) error {
+	return GroupAddUser(j.UA, j.Endpoint, group, user)
+}
+
+// GroupAddUser adds the user to the group, the user is identified by the
+// account id on Jira Cloud and by the name on Jira Server.
+func GroupAddUser(ua HttpClient, endpoint string, group string, user *jiradata.User) error {
+	body := map[string]string{}
+	if user.AccountID != "" {
+		body["accountId"] = user.AccountID
+	} else {
+		body["name"] = user.Name
+	}
+	encoded, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	uri := URLJoin(endpoint, "rest/api/2/group/user") + "?groupname=" + url.QueryEscape(group)
+	resp, err := ua.Post(uri, "application/json", bytes.NewBuffer(encoded))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 201 {
+		return nil
+	}
+	return responseError(resp)
+}
+
+// https://developer.atlassian.com/cloud/jira/platform/rest/v2/#api-rest-api-2-group-user-delete
+func (j *Jira) GroupRemoveUser(group string, user *jiradata.User) error {
+	return GroupRemoveUser(j.UA, j.Endpoint, group, user)
+}
+
+// GroupRemoveUser removes the user from the group.
+func GroupRemoveUser(ua HttpClient, endpoint string, group string, user *jiradata.User) error {
+	uri := URLJoin(endpoint, "rest/api/2/group/user") + "?groupname=" + url.QueryEscape(group)
+	if user.AccountID != "" {
+		uri += "&accountId=" + url.QueryEscape(user.AccountID)
+	} else {
+		uri += "&username=" + url.QueryEscape(user.Name)
+	}
+	resp, err := ua.Delete(uri)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 200 || resp.StatusCode == 204 {
+		return nil
+	}
+	return responseError(resp)
+}
@@ -144,22 +144,133 @@ func GetIssueComment(ua HttpClient, endpoint string, issue string) (*jiradata.Co
 	return &comments, nil
 }
 
+func (j *Jira) GetIssueChangelog(issue string) (*jiradata.Histories, error) {
+	return GetIssueChangelog(j.UA, j.Endpoint, issue)
+}
+
+// https://developer.atlassian.com/cloud/jira/platform/rest/v2/#api-rest-api-2-issue-issueIdOrKey-changelog-get
+func GetIssueChangelog(ua HttpClient, endpoint string, issue string) (*jiradata.Histories, error) {
+	startAt := 0
+	total := 1
+	maxResults := 100
+	histories := jiradata.Histories{}
+	for startAt < total {
+		uri := URLJoin(endpoint, "rest/api/2/issue", issue, "changelog")
+		uri += fmt.Sprintf("?startAt=%d&maxResults=%d", startAt, maxResults)
+		resp, err := ua.GetJSON(uri)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode == 200 {
+			results := &struct {
+				StartAt    int                `json:"startAt"`
+				MaxResults int                `json:"maxResults"`
+				Total      int                `json:"total"`
+				Values     jiradata.Histories `json:"values"`
+			}{}
+			err := json.NewDecoder(resp.Body).Decode(results)
+			if err != nil {
+				return nil, err
+			}
+			startAt = startAt + maxResults
+			total = results.Total
+			histories = append(histories, results.Values...)
+		} else {
+			return nil, responseError(resp)
+		}
+	}
+	return &histories, nil
+}
+
 type WorklogProvider interface {
 	ProvideWorklog() *jiradata.Worklog
 }
 
+type worklogConfig struct {
+	adjustEstimate string
+	newEstimate    string
+	reduceBy       string
+	increaseBy     string
+}
+
+func (c *worklogConfig) queryString() string {
+	params := []string{}
+	if c.adjustEstimate != "" {
+		params = append(params, "adjustEstimate="+url.QueryEscape(c.adjustEstimate))
+	}
+	if c.newEstimate != "" {
+		params = append(params, "newEstimate="+url.QueryEscape(c.newEstimate))
+	}
+	if c.reduceBy != "" {
+		params = append(params, "reduceBy="+url.QueryEscape(c.reduceBy))
+	}
+	if c.increaseBy != "" {
+		params = append(params, "increaseBy="+url.QueryEscape(c.increaseBy))
+	}
+	if len(params) > 0 {
+		return "?" + strings.Join(params, "&")
+	}
+	return ""
+}
+
+type WorklogOpt func(*worklogConfig)
+
+// WithAdjustEstimate controls how the remaining estimate of the issue is
+// updated when a worklog is added, updated or deleted.  Valid values are
+// "auto", "leave", "new" and "manual".
+func WithAdjustEstimate(adjust string) WorklogOpt {
+	return func(c *worklogConfig) {
+		c.adjustEstimate = adjust
+	}
+}
+
+// WithNewEstimate sets the remaining estimate (ie: "2d") when used with the
+// "new" adjustEstimate method.
+func WithNewEstimate(estimate string) WorklogOpt {
+	return func(c *worklogConfig) {
+		c.newEstimate = estimate
+	}
+}
+
+// WithReduceBy sets the amount to reduce the remaining estimate by when adding a
+// worklog with the "manual" adjustEstimate method.
+func WithReduceBy(amount string) WorklogOpt {
+	return func(c *worklogConfig) {
+		c.reduceBy = amount
+	}
+}
+
+// WithIncreaseBy sets the amount to increase the remaining estimate by when
+// deleting a worklog with the "manual" adjustEstimate method.
+func WithIncreaseBy(amount string) WorklogOpt {
+	return func(c *worklogConfig) {
+		c.increaseBy = amount
+	}
+}
+
+func newWorklogConfig(opts []WorklogOpt) *worklogConfig {
+	c := &worklogConfig{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
 // https://docs.atlassian.com/jira/REST/cloud/#api/2/issue/{issueIdOrKey}/worklog-addWorklog
-func (j *Jira) AddIssueWorklog(issue string, wp WorklogProvider) (*jiradata.Worklog, error) {
-	return AddIssueWorklog(j.UA, j.Endpoint, issue, wp)
+func (j *Jira) AddIssueWorklog(issue string, wp WorklogProvider, opts ...WorklogOpt) (*jiradata.Worklog, error) {
+	return AddIssueWorklog(j.UA, j.Endpoint, issue, wp, opts...)
 }
 
-func AddIssueWorklog(ua HttpClient, endpoint string, issue string, wp WorklogProvider) (*jiradata.Worklog, error) {
+func AddIssueWorklog(ua HttpClient, endpoint string, issue string, wp WorklogProvider, opts ...WorklogOpt) (*jiradata.Worklog, error) {
 	req := wp.ProvideWorklog()
 	encoded, err := json.Marshal(req)
 	if err != nil {
 		return nil, err
 	}
 	uri := URLJoin(endpoint, "rest/api/2/issue", issue, "worklog")
+	uri += newWorklogConfig(opts).queryString()
 	resp, err := ua.Post(uri, "application/json", bytes.NewBuffer(encoded))
 	if err != nil {
 		return nil, err
@@ -173,6 +284,72 @@ func AddIssueWorklog(ua HttpClient, endpoint string, issue string, wp WorklogPro
 	return nil, responseError(resp)
 }
 
+// https://docs.atlassian.com/jira/REST/cloud/#api/2/issue/{issueIdOrKey}/worklog-getWorklog
+func (j *Jira) GetIssueWorklogByID(issue, id string) (*jiradata.Worklog, error) {
+	return GetIssueWorklogByID(j.UA, j.Endpoint, issue, id)
+}
+
+func GetIssueWorklogByID(ua HttpClient, endpoint string, issue, id string) (*jiradata.Worklog, error) {
+	uri := URLJoin(endpoint, "rest/api/2/issue", issue, "worklog", id)
+	resp, err := ua.GetJSON(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 200 {
+		results := &jiradata.Worklog{}
+		return results, json.NewDecoder(resp.Body).Decode(results)
+	}
+	return nil, responseError(resp)
+}
+
+// https://docs.atlassian.com/jira/REST/cloud/#api/2/issue/{issueIdOrKey}/worklog-updateWorklog
+func (j *Jira) EditIssueWorklog(issue, id string, wp WorklogProvider, opts ...WorklogOpt) (*jiradata.Worklog, error) {
+	return EditIssueWorklog(j.UA, j.Endpoint, issue, id, wp, opts...)
+}
+
+func EditIssueWorklog(ua HttpClient, endpoint string, issue, id string, wp WorklogProvider, opts ...WorklogOpt) (*jiradata.Worklog, error) {
+	req := wp.ProvideWorklog()
+	encoded, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	uri := URLJoin(endpoint, "rest/api/2/issue", issue, "worklog", id)
+	uri += newWorklogConfig(opts).queryString()
+	resp, err := ua.Put(uri, "application/json", bytes.NewBuffer(encoded))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 200 {
+		results := &jiradata.Worklog{}
+		return results, json.NewDecoder(resp.Body).Decode(results)
+	}
+	return nil, responseError(resp)
+}
+
+// https://docs.atlassian.com/jira/REST/cloud/#api/2/issue/{issueIdOrKey}/worklog-deleteWorklog
+func (j *Jira) RemoveIssueWorklog(issue, id string, opts ...WorklogOpt) error {
+	return RemoveIssueWorklog(j.UA, j.Endpoint, issue, id, opts...)
+}
+
+func RemoveIssueWorklog(ua HttpClient, endpoint string, issue, id string, opts ...WorklogOpt) error {
+	uri := URLJoin(endpoint, "rest/api/2/issue", issue, "worklog", id)
+	uri += newWorklogConfig(opts).queryString()
+	resp, err := ua.Delete(uri)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 204 {
+		return nil
+	}
+	return responseError(resp)
+}
+
 // https://docs.atlassian.com/jira/REST/cloud/#api/2/issue-getEditIssueMeta
 func (j *Jira) GetIssueEditMeta(issue string) (*jiradata.EditMeta, error) {
 	return GetIssueEditMeta(j.UA, j.Endpoint, issue)
@@ -246,6 +423,30 @@ func CreateIssue(ua HttpClient, endpoint string, iup IssueUpdateProvider) (*jira
 	return nil, responseError(resp)
 }
 
+// https://docs.atlassian.com/jira/REST/cloud/#api/2/issue-deleteIssue
+func (j *Jira) DeleteIssue(issue string, deleteSubtasks bool) error {
+	return DeleteIssue(j.UA, j.Endpoint, issue, deleteSubtasks)
+}
+
+// DeleteIssue deletes the issue, an issue with subtasks can only be deleted
+// along with its subtasks.
+func DeleteIssue(ua HttpClient, endpoint string, issue string, deleteSubtasks bool) error {
+	uri := URLJoin(endpoint, "rest/api/2/issue", issue)
+	if deleteSubtasks {
+		uri += "?deleteSubtasks=true"
+	}
+	resp, err := ua.Delete(uri)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 204 {
+		return nil
+	}
+	return responseError(resp)
+}
+
 // https://docs.atlassian.com/jira/REST/cloud/#api/2/issue-getCreateIssueMeta
 func (j *Jira) GetIssueCreateMetaProject(projectKey string) (*jiradata.CreateMetaProject, error) {
 	return GetIssueCreateMetaProject(j.UA, j.Endpoint, projectKey)
@@ -338,6 +539,25 @@ func LinkIssues(ua HttpClient, endpoint string, lip LinkIssueProvider) error {
 	return responseError(resp)
 }
 
+// https://docs.atlassian.com/jira/REST/cloud/#api/2/issueLink-deleteIssueLink
+func (j *Jira) DeleteIssueLink(id string) error {
+	return DeleteIssueLink(j.UA, j.Endpoint, id)
+}
+
+func DeleteIssueLink(ua HttpClient, endpoint string, id string) error {
+	uri := URLJoin(endpoint, "rest/api/2/issueLink", id)
+	resp, err := ua.Delete(uri)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 204 {
+		return nil
+	}
+	return responseError(resp)
+}
+
 // https://docs.atlassian.com/jira/REST/cloud/#api/2/issue-getTransitions
 func (j *Jira) GetIssueTransitions(issue string) (*jiradata.TransitionsMeta, error) {
 	return GetIssueTransitions(j.UA, j.Endpoint, issue)
@@ -541,6 +761,70 @@ func IssueAddComment(ua HttpClient, endpoint string, issue string, cp CommentPro
 	return nil, responseError(resp)
 }
 
+// https://docs.atlassian.com/jira/REST/cloud/#api/2/issue/{issueIdOrKey}/comment-getComment
+func (j *Jira) GetIssueCommentByID(issue, id string) (*jiradata.Comment, error) {
+	return GetIssueCommentByID(j.UA, j.Endpoint, issue, id)
+}
+
+func GetIssueCommentByID(ua HttpClient, endpoint string, issue, id string) (*jiradata.Comment, error) {
+	uri := URLJoin(endpoint, "rest/api/2/issue", issue, "comment", id)
+	resp, err := ua.GetJSON(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 200 {
+		results := jiradata.Comment{}
+		return &results, json.NewDecoder(resp.Body).Decode(&results)
+	}
+	return nil, responseError(resp)
+}
+
+// https://docs.atlassian.com/jira/REST/cloud/#api/2/issue/{issueIdOrKey}/comment-updateComment
+func (j *Jira) IssueEditComment(issue, id string, cp CommentProvider) (*jiradata.Comment, error) {
+	return IssueEditComment(j.UA, j.Endpoint, issue, id, cp)
+}
+
+func IssueEditComment(ua HttpClient, endpoint string, issue, id string, cp CommentProvider) (*jiradata.Comment, error) {
+	req := cp.ProvideComment()
+	encoded, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	uri := URLJoin(endpoint, "rest/api/2/issue", issue, "comment", id)
+	resp, err := ua.Put(uri, "application/json", bytes.NewBuffer(encoded))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 200 {
+		results := jiradata.Comment{}
+		return &results, json.NewDecoder(resp.Body).Decode(&results)
+	}
+	return nil, responseError(resp)
+}
+
+// https://docs.atlassian.com/jira/REST/cloud/#api/2/issue/{issueIdOrKey}/comment-deleteComment
+func (j *Jira) IssueRemoveComment(issue, id string) error {
+	return IssueRemoveComment(j.UA, j.Endpoint, issue, id)
+}
+
+func IssueRemoveComment(ua HttpClient, endpoint string, issue, id string) error {
+	uri := URLJoin(endpoint, "rest/api/2/issue", issue, "comment", id)
+	resp, err := ua.Delete(uri)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 204 {
+		return nil
+	}
+	return responseError(resp)
+}
+
 type UserProvider interface {
 	ProvideUser() *jiradata.User
 }
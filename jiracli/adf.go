@@ -0,0 +1,762 @@
+package jiracli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/coryb/figtree"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiradata"
+)
+
+// The version 3 REST API of Jira Cloud uses the Atlassian Document Format
+// (ADF) for rich text fields.  These routines convert ADF documents to
+// Markdown and back so they can be rendered by templates and edited as text.
+// ADF nodes without Markdown syntax are written as JSON in an "adf" code
+// block or an "adf:" code span so they are not lost when editing.  Mentions
+// are written as [@Name](accountid:ID) and panels as quotes starting with a
+// [!INFO], [!NOTE], [!WARNING], [!SUCCESS] or [!ERROR] line.
+
+var (
+	adfMentionPattern = regexp.MustCompile(`\[(@[^\]]*)\]\(accountid:([^)\s]+)\)`)
+	adfItalicPattern  = regexp.MustCompile(`(^|[^\w*])\*(\S(?:[^*]*?\S)?)\*($|[^\w*])|(^|[^\w_])_(\S(?:[^_]*?\S)?)_($|[^\w_])`)
+	adfEmojiPattern   = regexp.MustCompile(`:([a-z][a-z0-9_+\-]*):`)
+	adfPanelPattern   = regexp.MustCompile(`^\[!(\w+)\]\s*$`)
+	adfBlankPattern   = regexp.MustCompile(`\n\s*\n`)
+)
+
+// adfInlinePatterns are tried in order at each position of the text, the
+// earliest match wins.
+var adfInlinePatterns = []*regexp.Regexp{
+	mdCodeSpanPattern,
+	adfMentionPattern,
+	mdLinkPattern,
+	mdAutoLinkPattern,
+	mdBoldPattern,
+	mdStrikePattern,
+	adfItalicPattern,
+	adfEmojiPattern,
+}
+
+// adfMarkdownMarks are the text marks with a Markdown syntax, text with any
+// other mark is kept as ADF.
+var adfMarkdownMarks = map[string]bool{
+	"code":   true,
+	"em":     true,
+	"link":   true,
+	"strike": true,
+	"strong": true,
+}
+
+// adfFields are the names of the fields holding ADF documents, the fields
+// found with ADF values in responses are added by the adfConverter.
+var adfFields = map[string]bool{
+	"body":        true,
+	"description": true,
+	"environment": true,
+}
+
+// adfMarkdown is true when the rich text fields are ADF documents converted to
+// Markdown rather than wiki markup, it is set up for the command being run.
+var adfMarkdown bool
+
+// MarkdownToADF converts Markdown text to an ADF document.
+func MarkdownToADF(text string) *jiradata.ADFNode {
+	text = strings.Replace(text, "\r\n", "\n", -1)
+	return jiradata.NewADFDocument(adfBlocks(strings.Split(text, "\n"))...)
+}
+
+// TextToADF converts plain text to an ADF document, blank lines separate
+// paragraphs.
+func TextToADF(text string) *jiradata.ADFNode {
+	text = strings.Replace(text, "\r\n", "\n", -1)
+	doc := jiradata.NewADFDocument()
+	for _, paragraph := range adfBlankPattern.Split(strings.TrimSpace(text), -1) {
+		if paragraph == "" {
+			continue
+		}
+		node := &jiradata.ADFNode{Type: "paragraph"}
+		for i, line := range strings.Split(paragraph, "\n") {
+			if i > 0 {
+				node.Content = append(node.Content, &jiradata.ADFNode{Type: "hardBreak"})
+			}
+			if line != "" {
+				node.Content = append(node.Content, adfText(line, nil))
+			}
+		}
+		doc.Content = append(doc.Content, node)
+	}
+	return doc
+}
+
+// ADFToMarkdown converts an ADF document to Markdown text.
+func ADFToMarkdown(doc *jiradata.ADFNode) string {
+	if doc == nil {
+		return ""
+	}
+	return strings.Join(adfMarkdownBlocks(doc.Content), "\n\n")
+}
+
+// ADFToText returns the text of an ADF document without formatting.
+func ADFToText(node *jiradata.ADFNode) string {
+	if node == nil {
+		return ""
+	}
+	switch node.Type {
+	case "text":
+		return node.Text
+	case "hardBreak":
+		return "\n"
+	case "mention", "emoji", "status", "inlineCard":
+		for _, name := range []string{"text", "shortName", "url"} {
+			if text := adfAttr(node, name); text != "" {
+				return text
+			}
+		}
+		return ""
+	}
+	separator := "\n"
+	switch node.Type {
+	case "paragraph", "heading", "codeBlock", "tableHeader", "tableCell":
+		separator = ""
+	case "tableRow":
+		separator = "\t"
+	case "doc":
+		separator = "\n\n"
+	}
+	parts := []string{}
+	for _, child := range node.Content {
+		parts = append(parts, ADFToText(child))
+	}
+	return strings.Join(parts, separator)
+}
+
+func adfAttr(node *jiradata.ADFNode, name string) string {
+	if value, ok := node.Attrs[name]; ok && value != nil {
+		return fmt.Sprint(value)
+	}
+	return ""
+}
+
+func adfText(text string, marks []*jiradata.ADFMark) *jiradata.ADFNode {
+	return &jiradata.ADFNode{Type: "text", Text: text, Marks: marks}
+}
+
+func adfWithMark(marks []*jiradata.ADFMark, mark *jiradata.ADFMark) []*jiradata.ADFMark {
+	result := append([]*jiradata.ADFMark{}, marks...)
+	return append(result, mark)
+}
+
+// adfDecode returns the nodes of an "adf" code block, which is either a node
+// or a list of nodes.
+func adfDecode(code string) ([]*jiradata.ADFNode, bool) {
+	nodes := []*jiradata.ADFNode{}
+	if err := json.Unmarshal([]byte(code), &nodes); err == nil {
+		return nodes, true
+	}
+	node := &jiradata.ADFNode{}
+	if err := json.Unmarshal([]byte(code), node); err != nil || node.Type == "" {
+		return nil, false
+	}
+	if node.Type == "doc" {
+		return node.Content, true
+	}
+	return []*jiradata.ADFNode{node}, true
+}
+
+func adfEncode(node *jiradata.ADFNode) string {
+	encoded, _ := json.Marshal(node)
+	return string(encoded)
+}
+
+// adfInline converts Markdown inline syntax to text nodes with marks.
+func adfInline(text string, marks []*jiradata.ADFMark) []*jiradata.ADFNode {
+	nodes := []*jiradata.ADFNode{}
+	for text != "" {
+		best, loc, start, end := -1, []int(nil), 0, 0
+		for i, pattern := range adfInlinePatterns {
+			l := pattern.FindStringSubmatchIndex(text)
+			if l == nil {
+				continue
+			}
+			s, e := adfMatchBounds(pattern, l)
+			if loc == nil || s < start {
+				best, loc, start, end = i, l, s, e
+			}
+		}
+		if loc == nil {
+			nodes = append(nodes, adfText(text, marks))
+			break
+		}
+		if start > 0 {
+			nodes = append(nodes, adfText(text[:start], marks))
+		}
+		group := func(n int) string {
+			if loc[2*n] < 0 {
+				return ""
+			}
+			return text[loc[2*n]:loc[2*n+1]]
+		}
+		switch adfInlinePatterns[best] {
+		case mdCodeSpanPattern:
+			if code := group(1); strings.HasPrefix(code, "adf:") {
+				if decoded, ok := adfDecode(code[4:]); ok {
+					nodes = append(nodes, decoded...)
+					break
+				}
+			}
+			nodes = append(nodes, adfText(group(1), adfWithMark(marks, &jiradata.ADFMark{Type: "code"})))
+		case adfMentionPattern:
+			nodes = append(nodes, &jiradata.ADFNode{
+				Type:  "mention",
+				Attrs: map[string]interface{}{"id": group(2), "text": group(1)},
+			})
+		case mdLinkPattern:
+			link := &jiradata.ADFMark{Type: "link", Attrs: map[string]interface{}{"href": group(2)}}
+			nodes = append(nodes, adfInline(group(1), adfWithMark(marks, link))...)
+		case mdAutoLinkPattern:
+			link := &jiradata.ADFMark{Type: "link", Attrs: map[string]interface{}{"href": group(1)}}
+			nodes = append(nodes, adfText(group(1), adfWithMark(marks, link)))
+		case mdBoldPattern:
+			nodes = append(nodes, adfInline(group(2), adfWithMark(marks, &jiradata.ADFMark{Type: "strong"}))...)
+		case mdStrikePattern:
+			nodes = append(nodes, adfInline(group(1), adfWithMark(marks, &jiradata.ADFMark{Type: "strike"}))...)
+		case adfItalicPattern:
+			inner := group(2)
+			if loc[4] < 0 {
+				inner = group(5)
+			}
+			nodes = append(nodes, adfInline(inner, adfWithMark(marks, &jiradata.ADFMark{Type: "em"}))...)
+		case adfEmojiPattern:
+			nodes = append(nodes, &jiradata.ADFNode{
+				Type:  "emoji",
+				Attrs: map[string]interface{}{"shortName": group(0)},
+			})
+		}
+		text = text[end:]
+	}
+	return nodes
+}
+
+// adfMatchBounds returns where the Markdown syntax of the match starts and
+// ends, the characters around the delimiters of italic text are only checked
+// so "2*3*4" is not italic.
+func adfMatchBounds(pattern *regexp.Regexp, loc []int) (int, int) {
+	if pattern != adfItalicPattern {
+		return loc[0], loc[1]
+	}
+	if loc[4] < 0 {
+		// matched the _italic_ alternative
+		return loc[9], loc[12]
+	}
+	return loc[3], loc[6]
+}
+
+// adfParagraph converts the lines of a Markdown paragraph, line breaks are
+// kept as hard breaks.
+func adfParagraph(lines []string) *jiradata.ADFNode {
+	node := &jiradata.ADFNode{Type: "paragraph"}
+	for i, line := range lines {
+		if i > 0 {
+			node.Content = append(node.Content, &jiradata.ADFNode{Type: "hardBreak"})
+		}
+		line = strings.TrimRight(strings.TrimSpace(line), `\`)
+		node.Content = append(node.Content, adfInline(line, nil)...)
+	}
+	return node
+}
+
+// adfTableRow converts a Markdown table row to a table row node.
+func adfTableRow(line, cellType string) *jiradata.ADFNode {
+	line = strings.TrimSpace(line)
+	line = strings.TrimSuffix(strings.TrimPrefix(line, "|"), "|")
+	line = strings.Replace(line, `\|`, "\x00", -1)
+	row := &jiradata.ADFNode{Type: "tableRow"}
+	for _, cell := range strings.Split(line, "|") {
+		cell = strings.TrimSpace(strings.Replace(cell, "\x00", "|", -1))
+		paragraph := &jiradata.ADFNode{Type: "paragraph"}
+		if cell != "" {
+			paragraph = adfParagraph([]string{cell})
+		}
+		row.Content = append(row.Content, &jiradata.ADFNode{
+			Type:    cellType,
+			Content: []*jiradata.ADFNode{paragraph},
+		})
+	}
+	return row
+}
+
+func adfDedent(lines []string) []string {
+	indent := -1
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if n := len(line) - len(strings.TrimLeft(line, " \t")); indent < 0 || n < indent {
+			indent = n
+		}
+	}
+	result := []string{}
+	for _, line := range lines {
+		if len(line) >= indent && indent > 0 {
+			line = line[indent:]
+		}
+		result = append(result, line)
+	}
+	return result
+}
+
+// adfListType returns the type of list node for the list item marker.
+func adfListType(marker string) string {
+	if marker[0] >= '0' && marker[0] <= '9' {
+		return "orderedList"
+	}
+	return "bulletList"
+}
+
+// adfList converts the lines of a Markdown list, more indented lines belong
+// to the previous item.
+func adfList(lines []string) *jiradata.ADFNode {
+	first := mdListPattern.FindStringSubmatch(lines[0])
+	indent := len(first[1])
+	list := &jiradata.ADFNode{Type: adfListType(first[2])}
+	if list.Type == "orderedList" {
+		if order, _ := strconv.Atoi(strings.TrimRight(first[2], ".)")); order > 1 {
+			list.Attrs = map[string]interface{}{"order": order}
+		}
+	}
+	var item *jiradata.ADFNode
+	nested := []string{}
+	flush := func() {
+		if item != nil && len(nested) > 0 {
+			item.Content = append(item.Content, adfBlocks(adfDedent(nested))...)
+		}
+		nested = []string{}
+	}
+	for _, line := range lines {
+		if m := mdListPattern.FindStringSubmatch(line); m != nil && len(m[1]) <= indent {
+			flush()
+			item = &jiradata.ADFNode{
+				Type:    "listItem",
+				Content: []*jiradata.ADFNode{adfParagraph([]string{m[3]})},
+			}
+			list.Content = append(list.Content, item)
+			continue
+		}
+		nested = append(nested, line)
+	}
+	flush()
+	return list
+}
+
+// adfBlockStart returns true when the line starts a block other than a
+// paragraph.
+func adfBlockStart(line string) bool {
+	return mdFencePattern.MatchString(line) || mdHeadingPattern.MatchString(line) ||
+		mdQuotePattern.MatchString(line) || mdListPattern.MatchString(line)
+}
+
+// adfBlocks converts lines of Markdown to ADF block nodes.
+func adfBlocks(lines []string) []*jiradata.ADFNode {
+	blocks := []*jiradata.ADFNode{}
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		if strings.TrimSpace(line) == "" {
+			i++
+			continue
+		}
+		if m := mdFencePattern.FindStringSubmatch(line); m != nil {
+			body := []string{}
+			for i++; i < len(lines) && strings.TrimSpace(lines[i]) != m[1]; i++ {
+				body = append(body, lines[i])
+			}
+			i++
+			code := strings.Join(body, "\n")
+			if m[2] == "adf" {
+				if nodes, ok := adfDecode(code); ok {
+					blocks = append(blocks, nodes...)
+					continue
+				}
+			}
+			node := &jiradata.ADFNode{Type: "codeBlock"}
+			if m[2] != "" {
+				node.Attrs = map[string]interface{}{"language": m[2]}
+			}
+			if code != "" {
+				node.Content = []*jiradata.ADFNode{adfText(code, nil)}
+			}
+			blocks = append(blocks, node)
+			continue
+		}
+		if m := mdHeadingPattern.FindStringSubmatch(line); m != nil {
+			blocks = append(blocks, &jiradata.ADFNode{
+				Type:    "heading",
+				Attrs:   map[string]interface{}{"level": len(m[1])},
+				Content: adfInline(m[2], nil),
+			})
+			i++
+			continue
+		}
+		if mdRulePattern.MatchString(line) {
+			blocks = append(blocks, &jiradata.ADFNode{Type: "rule"})
+			i++
+			continue
+		}
+		if mdQuotePattern.MatchString(line) {
+			quoted := []string{}
+			for ; i < len(lines) && mdQuotePattern.MatchString(lines[i]); i++ {
+				quoted = append(quoted, mdQuotePattern.FindStringSubmatch(lines[i])[1])
+			}
+			if m := adfPanelPattern.FindStringSubmatch(quoted[0]); m != nil {
+				blocks = append(blocks, &jiradata.ADFNode{
+					Type:    "panel",
+					Attrs:   map[string]interface{}{"panelType": strings.ToLower(m[1])},
+					Content: adfBlocks(quoted[1:]),
+				})
+				continue
+			}
+			blocks = append(blocks, &jiradata.ADFNode{Type: "blockquote", Content: adfBlocks(quoted)})
+			continue
+		}
+		if i+1 < len(lines) && strings.Contains(line, "|") && mdTableSepPattern.MatchString(lines[i+1]) {
+			table := &jiradata.ADFNode{Type: "table"}
+			table.Content = append(table.Content, adfTableRow(line, "tableHeader"))
+			for i += 2; i < len(lines) && strings.Contains(lines[i], "|"); i++ {
+				table.Content = append(table.Content, adfTableRow(lines[i], "tableCell"))
+			}
+			blocks = append(blocks, table)
+			continue
+		}
+		if first := mdListPattern.FindStringSubmatch(line); first != nil {
+			items := []string{}
+			for ; i < len(lines) && strings.TrimSpace(lines[i]) != ""; i++ {
+				m := mdListPattern.FindStringSubmatch(lines[i])
+				if len(items) > 0 && m == nil && strings.TrimLeft(lines[i], " \t") == lines[i] {
+					break
+				}
+				// a bullet list followed by a numbered list, or the
+				// reverse, are two lists
+				if len(items) > 0 && m != nil && len(m[1]) <= len(first[1]) && adfListType(m[2]) != adfListType(first[2]) {
+					break
+				}
+				items = append(items, lines[i])
+			}
+			blocks = append(blocks, adfList(items))
+			continue
+		}
+		paragraph := []string{line}
+		for i++; i < len(lines) && strings.TrimSpace(lines[i]) != "" && !adfBlockStart(lines[i]); i++ {
+			paragraph = append(paragraph, lines[i])
+		}
+		blocks = append(blocks, adfParagraph(paragraph))
+	}
+	return blocks
+}
+
+// adfFence writes a node without Markdown syntax as JSON in an "adf" code
+// block.
+func adfFence(node *jiradata.ADFNode) string {
+	return "```adf\n" + adfEncode(node) + "\n```"
+}
+
+func adfPrefix(text, prefix string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(prefix+line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+func adfMarkdownBlocks(nodes []*jiradata.ADFNode) []string {
+	blocks := []string{}
+	for _, node := range nodes {
+		blocks = append(blocks, adfMarkdownBlock(node))
+	}
+	return blocks
+}
+
+func adfMarkdownBlock(node *jiradata.ADFNode) string {
+	switch node.Type {
+	case "paragraph":
+		return adfMarkdownInline(node.Content)
+	case "heading":
+		level, _ := strconv.Atoi(adfAttr(node, "level"))
+		if level < 1 || level > 6 {
+			level = 1
+		}
+		return strings.Repeat("#", level) + " " + adfMarkdownInline(node.Content)
+	case "rule":
+		return "---"
+	case "codeBlock":
+		return "```" + adfAttr(node, "language") + "\n" + ADFToText(node) + "\n```"
+	case "blockquote":
+		return adfPrefix(strings.Join(adfMarkdownBlocks(node.Content), "\n\n"), "> ")
+	case "panel":
+		if len(node.Attrs) > 1 {
+			break
+		}
+		header := "[!" + strings.ToUpper(adfAttr(node, "panelType")) + "]"
+		return adfPrefix(header+"\n"+strings.Join(adfMarkdownBlocks(node.Content), "\n\n"), "> ")
+	case "bulletList", "orderedList":
+		order, _ := strconv.Atoi(adfAttr(node, "order"))
+		if order < 1 {
+			order = 1
+		}
+		items := []string{}
+		for i, item := range node.Content {
+			marker := "- "
+			if node.Type == "orderedList" {
+				marker = fmt.Sprintf("%d. ", order+i)
+			}
+			text := strings.Join(adfMarkdownBlocks(item.Content), "\n")
+			items = append(items, marker+strings.Replace(text, "\n", "\n"+strings.Repeat(" ", len(marker)), -1))
+		}
+		return strings.Join(items, "\n")
+	case "table":
+		if table, ok := adfMarkdownTable(node); ok {
+			return table
+		}
+	}
+	return adfFence(node)
+}
+
+// adfMarkdownTable writes a table as a Markdown table, which is only possible
+// when the first row is the header and every cell is a single line of text.
+func adfMarkdownTable(node *jiradata.ADFNode) (string, bool) {
+	rows := []string{}
+	for i, row := range node.Content {
+		cells := []string{}
+		for _, cell := range row.Content {
+			if (i == 0) != (cell.Type == "tableHeader") || len(cell.Content) > 1 {
+				return "", false
+			}
+			for _, name := range []string{"colspan", "rowspan"} {
+				if span := adfAttr(cell, name); span != "" && span != "1" {
+					return "", false
+				}
+			}
+			text := ""
+			if len(cell.Content) == 1 {
+				if cell.Content[0].Type != "paragraph" {
+					return "", false
+				}
+				text = adfMarkdownInline(cell.Content[0].Content)
+			}
+			if strings.Contains(text, "\n") {
+				return "", false
+			}
+			cells = append(cells, strings.Replace(text, "|", `\|`, -1))
+		}
+		if len(cells) == 0 {
+			return "", false
+		}
+		rows = append(rows, "| "+strings.Join(cells, " | ")+" |")
+		if i == 0 {
+			rows = append(rows, "|"+strings.Repeat(" --- |", len(cells)))
+		}
+	}
+	if len(rows) == 0 {
+		return "", false
+	}
+	return strings.Join(rows, "\n"), true
+}
+
+func adfMarkdownInline(nodes []*jiradata.ADFNode) string {
+	var buf bytes.Buffer
+	for _, node := range nodes {
+		switch node.Type {
+		case "text":
+			buf.WriteString(adfMarkdownText(node))
+		case "hardBreak":
+			buf.WriteString("\n")
+		case "mention":
+			text := adfAttr(node, "text")
+			if !strings.HasPrefix(text, "@") {
+				text = "@" + text
+			}
+			fmt.Fprintf(&buf, "[%s](accountid:%s)", text, adfAttr(node, "id"))
+		case "emoji":
+			if shortName := adfAttr(node, "shortName"); adfEmojiPattern.MatchString(shortName) {
+				buf.WriteString(shortName)
+				break
+			}
+			buf.WriteString("`adf:" + adfEncode(node) + "`")
+		default:
+			buf.WriteString("`adf:" + adfEncode(node) + "`")
+		}
+	}
+	return buf.String()
+}
+
+// adfMarkdownText writes a text node with its marks, the surrounding spaces
+// are kept outside of the Markdown syntax.
+func adfMarkdownText(node *jiradata.ADFNode) string {
+	for _, mark := range node.Marks {
+		if !adfMarkdownMarks[mark.Type] {
+			return "`adf:" + adfEncode(node) + "`"
+		}
+	}
+	text := node.Text
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return text
+	}
+	start := strings.Index(text, trimmed)
+	before, after := text[:start], text[start+len(trimmed):]
+	text = trimmed
+
+	wrap := map[string]string{"code": "`", "em": "*", "strong": "**", "strike": "~~"}
+	href := ""
+	for _, name := range []string{"code", "em", "strong", "strike", "link"} {
+		for _, mark := range node.Marks {
+			if mark.Type != name {
+				continue
+			}
+			if name == "link" {
+				if value, ok := mark.Attrs["href"]; ok {
+					href = fmt.Sprint(value)
+				}
+				continue
+			}
+			text = wrap[name] + text + wrap[name]
+		}
+	}
+	if href != "" {
+		text = "[" + text + "](" + href + ")"
+	}
+	return before + text + after
+}
+
+// adfConverter converts the rich text fields of the requests and responses
+// of the version 3 REST API.  The fields found with ADF values in responses,
+// like custom text fields, are remembered so they are converted back to ADF
+// documents when sent.
+type adfConverter struct {
+	apiVersion *figtree.StringOption
+	fields     map[string]bool
+}
+
+func newADFConverter(apiVersion *figtree.StringOption) *adfConverter {
+	fields := map[string]bool{}
+	for name := range adfFields {
+		fields[name] = true
+	}
+	return &adfConverter{apiVersion: apiVersion, fields: fields}
+}
+
+// toMarkdown replaces the ADF documents in the decoded JSON data with
+// Markdown text.
+func (c *adfConverter) toMarkdown(data interface{}) interface{} {
+	switch value := data.(type) {
+	case map[string]interface{}:
+		for k, v := range value {
+			if jiradata.IsADFDocument(v) {
+				encoded, err := json.Marshal(v)
+				if err != nil {
+					continue
+				}
+				doc := &jiradata.ADFNode{}
+				if err := json.Unmarshal(encoded, doc); err != nil {
+					continue
+				}
+				c.fields[k] = true
+				value[k] = ADFToMarkdown(doc)
+				continue
+			}
+			value[k] = c.toMarkdown(v)
+		}
+	case []interface{}:
+		for i, v := range value {
+			value[i] = c.toMarkdown(v)
+		}
+	}
+	return data
+}
+
+// toADF replaces the text of the ADF fields in the decoded JSON data with ADF
+// documents.
+func (c *adfConverter) toADF(data interface{}) interface{} {
+	switch value := data.(type) {
+	case map[string]interface{}:
+		for k, v := range value {
+			if text, ok := v.(string); ok && c.fields[k] {
+				if strings.TrimSpace(text) == "" {
+					value[k] = nil
+				} else {
+					value[k] = MarkdownToADF(text)
+				}
+				continue
+			}
+			value[k] = c.toADF(v)
+		}
+	case []interface{}:
+		for i, v := range value {
+			value[i] = c.toADF(v)
+		}
+	}
+	return data
+}
+
+// request sends requests for issues, comments, transitions and searches to
+// the version 3 REST API when it is configured, converting the Markdown text
+// of ADF fields in the request body to ADF documents.
+func (c *adfConverter) request(req *http.Request) (*http.Request, error) {
+	jira.SetAPIVersion(req.URL, c.apiVersion.Value)
+	if !strings.Contains(req.URL.Path, "/rest/api/3/") || req.Body == nil || req.Body == http.NoBody {
+		return req, nil
+	}
+	content, err := ioutil.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	var data interface{}
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+	if err := decoder.Decode(&data); err == nil {
+		if encoded, err := json.Marshal(c.toADF(data)); err == nil {
+			content = encoded
+		}
+	}
+	req.Body = ioutil.NopCloser(bytes.NewReader(content))
+	req.ContentLength = int64(len(content))
+	return req, nil
+}
+
+// response converts the ADF documents in responses of the version 3 REST API
+// to Markdown text, so templates and editing work as with version 2.
+func (c *adfConverter) response(req *http.Request, resp *http.Response) (*http.Response, error) {
+	if !strings.Contains(req.URL.Path, "/rest/api/3/") || resp.Body == nil ||
+		!strings.Contains(resp.Header.Get("Content-Type"), "json") {
+		return resp, nil
+	}
+	content, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	var data interface{}
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+	if err := decoder.Decode(&data); err == nil {
+		if encoded, err := json.Marshal(c.toMarkdown(data)); err == nil {
+			content = encoded
+		}
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(content))
+	resp.ContentLength = int64(len(content))
+	resp.Header.Del("Content-Length")
+	return resp, nil
+}
+
+// adfWiki converts the Markdown of ADF fields to wiki markup, so it is
+// rendered like the text of the version 2 REST API.
+func adfWiki(text string) string {
+	return MarkdownToWiki(adfMentionPattern.ReplaceAllString(text, "[~accountid:$2]"))
+}
@@ -0,0 +1,151 @@
+package jiracli
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/coryb/figtree"
+	"github.com/go-jira/jira/jiradata"
+	"github.com/stretchr/testify/assert"
+)
+
+func adfJSON(t *testing.T, node interface{}) string {
+	encoded, err := json.Marshal(node)
+	assert.NoError(t, err)
+	return string(encoded)
+}
+
+func TestMarkdownToADF(t *testing.T) {
+	for _, test := range []struct {
+		markdown string
+		adf      string
+	}{
+		{"plain", `[{"type":"paragraph","content":[{"type":"text","text":"plain"}]}]`},
+		{"# Title", `[{"type":"heading","attrs":{"level":1},"content":[{"type":"text","text":"Title"}]}]`},
+		{"**bold** *em* _em_ ~~del~~ `code`", `[{"type":"paragraph","content":[` +
+			`{"type":"text","text":"bold","marks":[{"type":"strong"}]},{"type":"text","text":" "},` +
+			`{"type":"text","text":"em","marks":[{"type":"em"}]},{"type":"text","text":" "},` +
+			`{"type":"text","text":"em","marks":[{"type":"em"}]},{"type":"text","text":" "},` +
+			`{"type":"text","text":"del","marks":[{"type":"strike"}]},{"type":"text","text":" "},` +
+			`{"type":"text","text":"code","marks":[{"type":"code"}]}]}]`},
+		{"2*3*4 and snake_case_name", `[{"type":"paragraph","content":[{"type":"text","text":"2*3*4 and snake_case_name"}]}]`},
+		{"(*a*) *b*", `[{"type":"paragraph","content":[{"type":"text","text":"("},` +
+			`{"type":"text","text":"a","marks":[{"type":"em"}]},{"type":"text","text":") "},` +
+			`{"type":"text","text":"b","marks":[{"type":"em"}]}]}]`},
+		{"[site](https://example.com) [@Jane](accountid:1) :smile:", `[{"type":"paragraph","content":[` +
+			`{"type":"text","text":"site","marks":[{"type":"link","attrs":{"href":"https://example.com"}}]},{"type":"text","text":" "},` +
+			`{"type":"mention","attrs":{"id":"1","text":"@Jane"}},{"type":"text","text":" "},` +
+			`{"type":"emoji","attrs":{"shortName":":smile:"}}]}]`},
+		{"a\nb", `[{"type":"paragraph","content":[{"type":"text","text":"a"},{"type":"hardBreak"},{"type":"text","text":"b"}]}]`},
+		{"- one\n- two\n1. first", `[` +
+			`{"type":"bulletList","content":[` +
+			`{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"one"}]}]},` +
+			`{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"two"}]}]}]},` +
+			`{"type":"orderedList","content":[` +
+			`{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"first"}]}]}]}]`},
+		{"3. three\n   - nested", `[{"type":"orderedList","attrs":{"order":3},"content":[` +
+			`{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"three"}]},` +
+			`{"type":"bulletList","content":[{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"nested"}]}]}]}]}]}]`},
+		{"> [!NOTE]\n> careful", `[{"type":"panel","attrs":{"panelType":"note"},"content":[` +
+			`{"type":"paragraph","content":[{"type":"text","text":"careful"}]}]}]`},
+		{"> quoted", `[{"type":"blockquote","content":[{"type":"paragraph","content":[{"type":"text","text":"quoted"}]}]}]`},
+		{"```go\nx\n```\n---", `[{"type":"codeBlock","attrs":{"language":"go"},"content":[{"type":"text","text":"x"}]},{"type":"rule"}]`},
+		{"| a |\n| --- |\n| 1 |", `[{"type":"table","content":[` +
+			`{"type":"tableRow","content":[{"type":"tableHeader","content":[{"type":"paragraph","content":[{"type":"text","text":"a"}]}]}]},` +
+			`{"type":"tableRow","content":[{"type":"tableCell","content":[{"type":"paragraph","content":[{"type":"text","text":"1"}]}]}]}]}]`},
+		{"```adf\n{\"type\":\"status\",\"attrs\":{\"text\":\"DONE\"}}\n```", `[{"type":"status","attrs":{"text":"DONE"}}]`},
+	} {
+		doc := MarkdownToADF(test.markdown)
+		assert.Equal(t, "doc", doc.Type)
+		assert.Equal(t, test.adf, adfJSON(t, doc.Content), test.markdown)
+	}
+}
+
+func TestADFToMarkdown(t *testing.T) {
+	for _, test := range []struct {
+		adf      string
+		markdown string
+	}{
+		{`[{"type":"heading","attrs":{"level":2},"content":[{"type":"text","text":"Title"}]}]`, "## Title"},
+		{`[{"type":"paragraph","content":[{"type":"text","text":"bold ","marks":[{"type":"strong"}]},` +
+			`{"type":"text","text":"link","marks":[{"type":"link","attrs":{"href":"https://example.com"}},{"type":"em"}]}]}]`,
+			"**bold** [*link*](https://example.com)"},
+		{`[{"type":"paragraph","content":[{"type":"text","text":"red","marks":[{"type":"textColor","attrs":{"color":"#ff0000"}}]}]}]`,
+			"`adf:" + `{"type":"text","text":"red","marks":[{"type":"textColor","attrs":{"color":"#ff0000"}}]}` + "`"},
+		{`[{"type":"paragraph","content":[{"type":"mention","attrs":{"id":"1","text":"Jane"}},{"type":"hardBreak"},{"type":"emoji","attrs":{"shortName":":smile:"}}]}]`,
+			"[@Jane](accountid:1)\n:smile:"},
+		{`[{"type":"orderedList","attrs":{"order":2},"content":[` +
+			`{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"a"}]},` +
+			`{"type":"bulletList","content":[{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"b"}]}]}]}]}]},` +
+			`{"type":"rule"}]`, "2. a\n   - b\n\n---"},
+		{`[{"type":"panel","attrs":{"panelType":"warning"},"content":[{"type":"paragraph","content":[{"type":"text","text":"hot"}]}]}]`,
+			"> [!WARNING]\n> hot"},
+		{`[{"type":"codeBlock","content":[{"type":"text","text":"x := 1"}]}]`, "```\nx := 1\n```"},
+		{`[{"type":"mediaSingle","content":[{"type":"media","attrs":{"id":"1"}}]}]`,
+			"```adf\n" + `{"type":"mediaSingle","content":[{"type":"media","attrs":{"id":"1"}}]}` + "\n```"},
+	} {
+		doc := jiradata.NewADFDocument()
+		assert.NoError(t, json.Unmarshal([]byte(test.adf), &doc.Content))
+		assert.Equal(t, test.markdown, ADFToMarkdown(doc), test.adf)
+	}
+}
+
+func TestADFRoundTrip(t *testing.T) {
+	for _, markdown := range []string{
+		"# Title\n\nSome **bold**, *em* and `code` with [a link](https://example.com).",
+		"- one\n- two\n\n1. first\n2. second",
+		"> [!INFO]\n> note\n\n| a | b |\n| --- | --- |\n| 1 | 2 |",
+		"```adf\n{\"type\":\"status\",\"attrs\":{\"text\":\"DONE\"}}\n```",
+	} {
+		assert.Equal(t, markdown, ADFToMarkdown(MarkdownToADF(markdown)))
+	}
+}
+
+func TestADFConverter(t *testing.T) {
+	version := figtree.NewStringOption("3")
+	c := newADFConverter(&version)
+
+	resp := &http.Response{
+		Header: http.Header{"Content-Type": []string{"application/json"}},
+		Body: ioutil.NopCloser(bytes.NewBufferString(`{"fields":{"summary":"s","customfield_1":` +
+			`{"type":"doc","version":1,"content":[{"type":"paragraph","content":[{"type":"text","text":"hi"}]}]}}}`)),
+	}
+	req, _ := http.NewRequest("GET", "https://example.atlassian.net/rest/api/2/issue/ABC-1", nil)
+	req, err := c.request(req)
+	assert.NoError(t, err)
+	assert.Equal(t, "/rest/api/3/issue/ABC-1", req.URL.Path)
+	resp, err = c.response(req, resp)
+	assert.NoError(t, err)
+	body, _ := ioutil.ReadAll(resp.Body)
+	assert.Equal(t, `{"fields":{"customfield_1":"hi","summary":"s"}}`, string(body))
+	// the custom field is only known to this converter
+	assert.True(t, c.fields["customfield_1"])
+	assert.False(t, adfFields["customfield_1"])
+
+	req, _ = http.NewRequest("PUT", "https://example.atlassian.net/rest/api/2/issue/ABC-1",
+		bytes.NewBufferString(`{"fields":{"summary":"s","customfield_1":"*new*","description":""}}`))
+	req, err = c.request(req)
+	assert.NoError(t, err)
+	body, _ = ioutil.ReadAll(req.Body)
+	assert.Equal(t, `{"fields":{"customfield_1":{"type":"doc","version":1,"content":[`+
+		`{"type":"paragraph","content":[{"type":"text","text":"new","marks":[{"type":"em"}]}]}]},"description":null,"summary":"s"}}`, string(body))
+
+	// other resources stay on version 2
+	req, _ = http.NewRequest("GET", "https://example.atlassian.net/rest/api/2/project/ABC", nil)
+	req, err = c.request(req)
+	assert.NoError(t, err)
+	assert.Equal(t, "/rest/api/2/project/ABC", req.URL.Path)
+
+	version.Value = "2"
+	req, _ = http.NewRequest("GET", "https://example.atlassian.net/rest/api/2/search", nil)
+	req, err = c.request(req)
+	assert.NoError(t, err)
+	assert.Equal(t, "/rest/api/2/search", req.URL.Path)
+}
+
+func TestADFWiki(t *testing.T) {
+	assert.Equal(t, "h1. Title\n* item [~accountid:1]", adfWiki("# Title\n- item [@Jane](accountid:1)"))
+}
@@ -111,6 +111,37 @@ type GlobalOptions struct {
 	// JiraDeploymentType can be `cloud` or `server`, if not set it will be inferred from
 	// the /rest/api/2/serverInfo REST API.
 	JiraDeploymentType figtree.StringOption `yaml:"jira-deployment-type,omitempty" json:"jira-deployment-type,omitempty"`
+
+	// APIVersion is the version of the REST API used for issues, comments, transitions and searches, it can be
+	// "2" or "3".  Version 3 is only available on Jira Cloud and uses the Atlassian Document Format for rich text
+	// fields, which are converted to and from Markdown.  The default is "2".
+	APIVersion figtree.StringOption `yaml:"api-version,omitempty" json:"api-version,omitempty"`
+}
+
+// apiVersions are the supported versions of the REST API.
+var apiVersions = []string{"2", "3"}
+
+// enumOption restricts the values of a string option, like EnumVar does for
+// plain strings, without losing the precedence of the flag over the configs.
+type enumOption struct {
+	*figtree.StringOption
+	values []string
+}
+
+func (o *enumOption) validate(value string) error {
+	for _, v := range o.values {
+		if v == value {
+			return nil
+		}
+	}
+	return fmt.Errorf("enum value must be one of %s, got '%s'", strings.Join(o.values, ","), value)
+}
+
+func (o *enumOption) Set(value string) error {
+	if err := o.validate(value); err != nil {
+		return err
+	}
+	return o.StringOption.Set(value)
 }
 
 type CommonOptions struct {
@@ -118,6 +149,7 @@ type CommonOptions struct {
 	Editor      figtree.StringOption `yaml:"editor,omitempty" json:"editor,omitempty"`
 	File        figtree.StringOption `yaml:"file,omitempty" json:"file,omitempty"`
 	GJsonQuery  figtree.StringOption `yaml:"gjq,omitempty" json:"gjq,omitempty"`
+	Markdown    figtree.BoolOption   `yaml:"markdown,omitempty" json:"markdown,omitempty"`
 	SkipEditing figtree.BoolOption   `yaml:"noedit,omitempty" json:"noedit,omitempty"`
 	Template    figtree.StringOption `yaml:"template,omitempty" json:"template,omitempty"`
 }
@@ -133,6 +165,10 @@ type CommandRegistry struct {
 	Aliases []string
 	Entry   *CommandRegistryEntry
 	Default bool
+	// Operation is used for JIRA_OPERATION and the command specific config
+	// file instead of Command, so commands that became subcommands keep
+	// loading the same configs
+	Operation string
 }
 
 // either kingpin.Application or kingpin.CmdClause fit this interface
@@ -143,6 +179,17 @@ type kingpinAppOrCommand interface {
 
 var globalCommandRegistry = []CommandRegistry{}
 
+// commandOperations maps the full command to the Operation of the registry
+// entry, when set
+var commandOperations = map[string]string{}
+
+func operation(cmd *kingpin.CmdClause) string {
+	if op, ok := commandOperations[cmd.FullCommand()]; ok {
+		return op
+	}
+	return cmd.FullCommand()
+}
+
 func RegisterCommand(regEntry CommandRegistry) {
 	globalCommandRegistry = append(globalCommandRegistry, regEntry)
 }
@@ -158,6 +205,7 @@ func register(app *kingpin.Application, o *oreo.Client, fig *figtree.FigTree) {
 	globals := GlobalOptions{
 		User:                 figtree.NewStringOption(os.Getenv("USER")),
 		AuthenticationMethod: figtree.NewStringOption("session"),
+		APIVersion:           figtree.NewStringOption("2"),
 	}
 	app.Flag("endpoint", "Base URI to use for Jira").Short('e').SetValue(&globals.Endpoint)
 	app.Flag("insecure", "Disable TLS certificate verification").Short('k').SetValue(&globals.Insecure)
@@ -166,6 +214,7 @@ func register(app *kingpin.Application, o *oreo.Client, fig *figtree.FigTree) {
 	app.Flag("socksproxy", "Address for a socks proxy").SetValue(&globals.SocksProxy)
 	app.Flag("user", "user name used within the Jira service").Short('u').SetValue(&globals.User)
 	app.Flag("login", "login name that corresponds to the user used for authentication").SetValue(&globals.Login)
+	app.Flag("api-version", "REST API version for issues and comments, 3 uses Markdown for Atlassian Document Format fields").HintOptions(apiVersions...).SetValue(&enumOption{&globals.APIVersion, apiVersions})
 
 	o = o.WithPreCallback(func(req *http.Request) (*http.Request, error) {
 		if globals.AuthMethod() == "api-token" {
@@ -177,6 +226,9 @@ func register(app *kingpin.Application, o *oreo.Client, fig *figtree.FigTree) {
 		return req, nil
 	})
 
+	adf := newADFConverter(&globals.APIVersion)
+	o = o.WithPreCallback(adf.request)
+
 	o = o.WithPostCallback(func(req *http.Request, resp *http.Response) (*http.Response, error) {
 		if globals.AuthMethod() == "session" {
 			authUser := resp.Header.Get("X-Ausername")
@@ -201,6 +253,8 @@ func register(app *kingpin.Application, o *oreo.Client, fig *figtree.FigTree) {
 		return resp, nil
 	})
 
+	o = o.WithPostCallback(adf.response)
+
 	for _, command := range globalCommandRegistry {
 		copy := command
 		commandFields := strings.Fields(copy.Command)
@@ -216,6 +270,9 @@ func register(app *kingpin.Application, o *oreo.Client, fig *figtree.FigTree) {
 		}
 
 		cmd := appOrCmd.Command(commandFields[len(commandFields)-1], copy.Entry.Help)
+		if copy.Operation != "" {
+			commandOperations[cmd.FullCommand()] = copy.Operation
+		}
 		LoadConfigs(cmd, fig, &globals)
 		cmd.PreAction(func(_ *kingpin.ParseContext) error {
 			if globals.Insecure.Value {
@@ -238,6 +295,10 @@ func register(app *kingpin.Application, o *oreo.Client, fig *figtree.FigTree) {
 			if globals.Login.Value == "" {
 				globals.Login = globals.User
 			}
+			// the api-version may also be set in the configs
+			if err := (&enumOption{&globals.APIVersion, apiVersions}).validate(globals.APIVersion.Value); err != nil {
+				return fmt.Errorf("Invalid api-version: %s", err)
+			}
 			return nil
 		})
 
@@ -255,6 +316,8 @@ func register(app *kingpin.Application, o *oreo.Client, fig *figtree.FigTree) {
 			if logging.GetLevel("") > logging.DEBUG {
 				o = o.WithTrace(true)
 			}
+			mentions.ua, mentions.globals = o, &globals
+			adfMarkdown = globals.APIVersion.Value == "3"
 			return copy.Entry.ExecuteFunc(o, &globals)
 		})
 	}
@@ -262,9 +325,9 @@ func register(app *kingpin.Application, o *oreo.Client, fig *figtree.FigTree) {
 
 func LoadConfigs(cmd *kingpin.CmdClause, fig *figtree.FigTree, opts interface{}) {
 	cmd.PreAction(func(_ *kingpin.ParseContext) error {
-		os.Setenv("JIRA_OPERATION", cmd.FullCommand())
+		os.Setenv("JIRA_OPERATION", operation(cmd))
 		// load command specific configs first
-		if err := fig.LoadAllConfigs(strings.Join(strings.Fields(cmd.FullCommand()), "_")+".yml", opts); err != nil {
+		if err := fig.LoadAllConfigs(strings.Join(strings.Fields(operation(cmd)), "_")+".yml", opts); err != nil {
 			return err
 		}
 		// then load generic configs if not already populated above
@@ -280,6 +343,10 @@ func EditorUsage(cmd *kingpin.CmdClause, opts *CommonOptions) {
 	cmd.Flag("editor", "Editor to use").SetValue(&opts.Editor)
 }
 
+func MarkdownUsage(cmd *kingpin.CmdClause, opts *CommonOptions) {
+	cmd.Flag("markdown", "Edit descriptions and comments as Markdown, converted to and from wiki markup, they are always Markdown with --api-version 3").SetValue(&opts.Markdown)
+}
+
 func FileUsage(cmd *kingpin.CmdClause, opts *CommonOptions) {
 	cmd.Flag("file", "File to use").SetValue(&opts.File)
 }
@@ -376,7 +443,38 @@ func (o *CommonOptions) editFile(fileName string) (changes bool, err error) {
 
 var EditLoopAbort = fmt.Errorf("edit Loop aborted by request")
 
+// markdownInput converts the text fields of the input from wiki markup to
+// Markdown when editing Markdown, unless they are ADF fields already converted
+// to Markdown.  The conversion is lossy, so the returned function converts the
+// edited Markdown back to wiki markup but keeps the original wiki markup of
+// the fields whose Markdown was not changed.
+func (o *CommonOptions) markdownInput(input interface{}) (interface{}, func(string) string, error) {
+	if !o.Markdown.Value || adfMarkdown {
+		return input, nil, nil
+	}
+	var raw interface{}
+	if err := ConvertType(input, &raw); err != nil {
+		return nil, nil, err
+	}
+	original := map[string]string{}
+	raw = convertMarkdownFields(raw, func(wiki string) string {
+		text := WikiToMarkdown(wiki)
+		original[strings.TrimSpace(text)] = wiki
+		return text
+	})
+	return raw, func(text string) string {
+		if wiki, ok := original[strings.TrimSpace(text)]; ok {
+			return wiki
+		}
+		return MarkdownToWiki(text)
+	}, nil
+}
+
 func EditLoop(opts *CommonOptions, input interface{}, output interface{}, submit func() error) error {
+	input, toWiki, err := opts.markdownInput(input)
+	if err != nil {
+		return err
+	}
 	tmpFile, err := tmpTemplate(opts.Template.Value, input)
 	if err != nil {
 		return err
@@ -451,6 +549,16 @@ func EditLoop(opts *CommonOptions, input interface{}, output interface{}, submit
 			return EditLoopAbort
 		}
 		yamlFixup(&raw)
+		if toWiki != nil {
+			convertMarkdownFields(raw, toWiki)
+		}
+		if err := mentions.expandFields(raw); err != nil {
+			log.Error(err.Error())
+			if confirm(true, "Unable to resolve mentions, edit again?") {
+				continue
+			}
+			return EditLoopAbort
+		}
 		fixedYAML, err := yaml.Marshal(&raw)
 		if err != nil {
 			log.Error(err.Error())
@@ -532,6 +640,14 @@ func ReadYmlInputFile(opts *CommonOptions, input interface{}, output interface{}
 		return FileAbort
 	}
 	yamlFixup(&raw)
+	if opts.Markdown.Value && !adfMarkdown {
+		convertMarkdownFields(raw, MarkdownToWiki)
+	}
+	if err := mentions.expandFields(raw); err != nil {
+		log.Error(err.Error())
+		fmt.Printf("Unable to resolve mentions\n")
+		return FileAbort
+	}
 	fixedYAML, err := yaml.Marshal(&raw)
 	if err != nil {
 		log.Error(err.Error())
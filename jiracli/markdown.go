@@ -0,0 +1,294 @@
+package jiracli
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Jira Server renders text fields as wiki markup, these routines convert the
+// common Markdown syntax to wiki markup and back so descriptions and comments
+// can be written in Markdown.
+
+var (
+	mdFencePattern     = regexp.MustCompile("^\\s*(```|~~~)\\s*([\\w+#-]*)\\s*$")
+	mdHeadingPattern   = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	mdQuotePattern     = regexp.MustCompile(`^>\s?(.*)$`)
+	mdListPattern      = regexp.MustCompile(`^(\s*)([-*+]|\d+[.)])\s+(.*)$`)
+	mdRulePattern      = regexp.MustCompile(`^\s*(?:(?:-\s*){3,}|(?:\*\s*){3,}|(?:_\s*){3,})$`)
+	mdTableSepPattern  = regexp.MustCompile(`^\s*\|?(\s*:?-+:?\s*\|)+\s*(:?-+:?\s*)?$`)
+	mdCodeSpanPattern  = regexp.MustCompile("`([^`]+)`")
+	mdImagePattern     = regexp.MustCompile(`!\[[^\]]*\]\(([^)\s]+)\)`)
+	mdLinkPattern      = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	mdAutoLinkPattern  = regexp.MustCompile(`<((?:https?|mailto):[^>\s]+)>`)
+	mdBoldPattern      = regexp.MustCompile(`(\*\*|__)(\S(?:.*?\S)?)(\*\*|__)`)
+	mdItalicPattern    = regexp.MustCompile(`(^|[^\w*])[*_](\S(?:[^*_]*\S)?)[*_]($|[^\w*])`)
+	mdStrikePattern    = regexp.MustCompile(`~~(\S(?:.*?\S)?)~~`)
+	wikiCodePattern    = regexp.MustCompile(`^\s*\{(code|noformat)(?::([^}]*))?\}\s*(.*)$`)
+	wikiCodeEndPattern = regexp.MustCompile(`^(.*?)\{(code|noformat)\}\s*$`)
+	wikiHeadingPattern = regexp.MustCompile(`^\s*h([1-6])\.\s+(.*)$`)
+	wikiQuotePattern   = regexp.MustCompile(`^\s*bq\.\s+(.*)$`)
+	wikiListPattern    = regexp.MustCompile(`^\s*([*#-]+)\s+(.*)$`)
+	wikiRulePattern    = regexp.MustCompile(`^\s*-{4,}\s*$`)
+	wikiMonoPattern    = regexp.MustCompile(`\{\{(.+?)\}\}`)
+	wikiImagePattern   = regexp.MustCompile(`!([^!\s|]+)(?:\|[^!]*)?!`)
+	wikiLinkPattern    = regexp.MustCompile(`\[([^\]|~^]+)\|([^\]]+)\]`)
+	wikiURLPattern     = regexp.MustCompile(`\[((?:https?|mailto):[^\]|]+)\]`)
+	wikiBoldPattern    = regexp.MustCompile(`(^|[^\w*])\*(\S(?:[^*]*\S)?)\*($|[^\w*])`)
+	wikiItalicPattern  = regexp.MustCompile(`(^|[^\w_])_(\S(?:[^_]*\S)?)_($|[^\w_])`)
+	wikiStrikePattern  = regexp.MustCompile(`(^|\s)-(\S(?:[^-]*\S)?)-($|\s)`)
+)
+
+// protect replaces the matches of the pattern with placeholders so they are
+// not converted further, the returned function restores them.
+func protect(text string, pattern *regexp.Regexp, replace func([]string) string) (string, func(string) string) {
+	saved := []string{}
+	text = pattern.ReplaceAllStringFunc(text, func(match string) string {
+		saved = append(saved, replace(pattern.FindStringSubmatch(match)))
+		return fmt.Sprintf("\x00%p:%d\x00", pattern, len(saved)-1)
+	})
+	return text, func(text string) string {
+		for i, s := range saved {
+			text = strings.Replace(text, fmt.Sprintf("\x00%p:%d\x00", pattern, i), s, 1)
+		}
+		return text
+	}
+}
+
+// replaceRepeat replaces the matches of patterns that share a delimiter with
+// their neighbours, like "*a* *b*", until there are none left.
+func replaceRepeat(pattern *regexp.Regexp, text, repl string) string {
+	for {
+		replaced := pattern.ReplaceAllString(text, repl)
+		if replaced == text {
+			return text
+		}
+		text = replaced
+	}
+}
+
+func markdownInline(text string) string {
+	text, restoreCode := protect(text, mdCodeSpanPattern, func(m []string) string {
+		return "{{" + m[1] + "}}"
+	})
+	text, restoreLinks := protect(text, mdImagePattern, func(m []string) string {
+		return "!" + m[1] + "!"
+	})
+	text = mdLinkPattern.ReplaceAllString(text, "[$1|$2]")
+	text = mdAutoLinkPattern.ReplaceAllString(text, "[$1]")
+	text, restoreBold := protect(text, mdBoldPattern, func(m []string) string {
+		return "*" + m[2] + "*"
+	})
+	text = replaceRepeat(mdItalicPattern, text, "${1}_${2}_${3}")
+	text = mdStrikePattern.ReplaceAllString(text, "-$1-")
+	return restoreCode(restoreLinks(restoreBold(text)))
+}
+
+func markdownTableRow(line string, header bool) string {
+	cells := strings.Split(strings.Trim(strings.TrimSpace(line), "|"), "|")
+	sep := "|"
+	if header {
+		sep = "||"
+	}
+	for i, cell := range cells {
+		cells[i] = markdownInline(strings.TrimSpace(cell))
+	}
+	return sep + strings.Join(cells, sep) + sep
+}
+
+// MarkdownToWiki converts Markdown text to Jira wiki markup.
+func MarkdownToWiki(text string) string {
+	lines := strings.Split(text, "\n")
+	out := []string{}
+	fence := ""
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		if fence != "" {
+			if strings.TrimSpace(line) == fence {
+				out = append(out, "{code}")
+				fence = ""
+			} else {
+				out = append(out, line)
+			}
+			continue
+		}
+		if m := mdFencePattern.FindStringSubmatch(line); m != nil {
+			fence = m[1]
+			if m[2] != "" {
+				out = append(out, "{code:"+m[2]+"}")
+			} else {
+				out = append(out, "{code}")
+			}
+			continue
+		}
+		if strings.HasPrefix(strings.TrimSpace(line), "|") && i+1 < len(lines) && mdTableSepPattern.MatchString(lines[i+1]) {
+			out = append(out, markdownTableRow(line, true))
+			i++
+			for i+1 < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i+1]), "|") {
+				i++
+				out = append(out, markdownTableRow(lines[i], false))
+			}
+			continue
+		}
+		switch {
+		case mdRulePattern.MatchString(line):
+			out = append(out, "----")
+		case mdHeadingPattern.MatchString(line):
+			m := mdHeadingPattern.FindStringSubmatch(line)
+			out = append(out, fmt.Sprintf("h%d. %s", len(m[1]), markdownInline(m[2])))
+		case mdQuotePattern.MatchString(line):
+			out = append(out, "bq. "+markdownInline(mdQuotePattern.FindStringSubmatch(line)[1]))
+		case mdListPattern.MatchString(line):
+			m := mdListPattern.FindStringSubmatch(line)
+			marker := "*"
+			if m[2] != "-" && m[2] != "*" && m[2] != "+" {
+				marker = "#"
+			}
+			depth := len(strings.Replace(m[1], "\t", "  ", -1))/2 + 1
+			out = append(out, strings.Repeat(marker, depth)+" "+markdownInline(m[3]))
+		default:
+			out = append(out, markdownInline(line))
+		}
+	}
+	if fence != "" {
+		out = append(out, "{code}")
+	}
+	return strings.Join(out, "\n")
+}
+
+func wikiInline(text string) string {
+	text, restoreCode := protect(text, wikiMonoPattern, func(m []string) string {
+		return "`" + m[1] + "`"
+	})
+	text, restoreLinks := protect(text, wikiImagePattern, func(m []string) string {
+		return "![](" + m[1] + ")"
+	})
+	text, restoreURLs := protect(text, wikiLinkPattern, func(m []string) string {
+		return "[" + m[1] + "](" + m[2] + ")"
+	})
+	text = wikiURLPattern.ReplaceAllString(text, "<$1>")
+	text, restoreBold := protect(text, wikiBoldPattern, func(m []string) string {
+		return m[1] + "**" + m[2] + "**" + m[3]
+	})
+	text = replaceRepeat(wikiItalicPattern, text, "${1}*${2}*${3}")
+	text = replaceRepeat(wikiStrikePattern, text, "${1}~~${2}~~${3}")
+	return restoreCode(restoreLinks(restoreURLs(restoreBold(text))))
+}
+
+func wikiTableRow(line string) (string, int, bool) {
+	line = strings.TrimSpace(line)
+	header := strings.HasPrefix(line, "||")
+	cells := strings.Split(strings.Trim(strings.Replace(line, "||", "|", -1), "|"), "|")
+	for i, cell := range cells {
+		cells[i] = wikiInline(strings.TrimSpace(cell))
+	}
+	return "| " + strings.Join(cells, " | ") + " |", len(cells), header
+}
+
+// WikiToMarkdown converts Jira wiki markup to Markdown text.
+func WikiToMarkdown(text string) string {
+	lines := strings.Split(strings.Replace(text, "\r\n", "\n", -1), "\n")
+	out := []string{}
+	code := ""
+	quote := false
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		if code != "" {
+			if m := wikiCodeEndPattern.FindStringSubmatch(line); m != nil && m[2] == code {
+				if m[1] != "" {
+					out = append(out, m[1])
+				}
+				out = append(out, "```")
+				code = ""
+			} else {
+				out = append(out, line)
+			}
+			continue
+		}
+		if m := wikiCodePattern.FindStringSubmatch(line); m != nil {
+			code = m[1]
+			out = append(out, "```"+wikiCodeLanguage(m[2]))
+			if rest := m[3]; rest != "" {
+				if end := wikiCodeEndPattern.FindStringSubmatch(rest); end != nil && end[2] == code {
+					if end[1] != "" {
+						out = append(out, end[1])
+					}
+					out = append(out, "```")
+					code = ""
+				} else {
+					out = append(out, rest)
+				}
+			}
+			continue
+		}
+		if strings.TrimSpace(line) == "{quote}" {
+			quote = !quote
+			continue
+		}
+		prefix := ""
+		if quote {
+			prefix = "> "
+		}
+		if strings.HasPrefix(strings.TrimSpace(line), "|") {
+			row, cells, header := wikiTableRow(line)
+			out = append(out, prefix+row)
+			if header {
+				out = append(out, prefix+"|"+strings.Repeat(" --- |", cells))
+			}
+			continue
+		}
+		switch {
+		case wikiRulePattern.MatchString(line):
+			out = append(out, prefix+"---")
+		case wikiHeadingPattern.MatchString(line):
+			m := wikiHeadingPattern.FindStringSubmatch(line)
+			out = append(out, prefix+strings.Repeat("#", int(m[1][0]-'0'))+" "+wikiInline(m[2]))
+		case wikiQuotePattern.MatchString(line):
+			out = append(out, "> "+wikiInline(wikiQuotePattern.FindStringSubmatch(line)[1]))
+		case wikiListPattern.MatchString(line):
+			m := wikiListPattern.FindStringSubmatch(line)
+			marker := "-"
+			if strings.HasSuffix(m[1], "#") {
+				marker = "1."
+			}
+			out = append(out, prefix+strings.Repeat("  ", len(m[1])-1)+marker+" "+wikiInline(m[2]))
+		default:
+			out = append(out, prefix+wikiInline(line))
+		}
+	}
+	if code != "" {
+		out = append(out, "```")
+	}
+	return strings.Join(out, "\n")
+}
+
+// markdownFields are the keys of text fields converted between Markdown and
+// wiki markup.
+var markdownFields = map[string]bool{
+	"body":        true,
+	"description": true,
+	"environment": true,
+}
+
+// convertMarkdownFields converts the text fields found in the data with the
+// converter, skipping the overrides given on the command line.
+func convertMarkdownFields(data interface{}, convert func(string) string) interface{} {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if key == "overrides" {
+				continue
+			}
+			if text, ok := value.(string); ok && markdownFields[key] {
+				v[key] = convert(text)
+			} else {
+				v[key] = convertMarkdownFields(value, convert)
+			}
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = convertMarkdownFields(value, convert)
+		}
+	}
+	return data
+}
@@ -0,0 +1,78 @@
+package jiracli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarkdownToWiki(t *testing.T) {
+	for _, test := range []struct {
+		markdown string
+		wiki     string
+	}{
+		{"# Title", "h1. Title"},
+		{"### Sub ###", "h3. Sub"},
+		{"**bold** and *italic* and _also_", "*bold* and _italic_ and _also_"},
+		{"2*3*4 stays", "2*3*4 stays"},
+		{"~~gone~~", "-gone-"},
+		{"use `a*b*c` here", "use {{a*b*c}} here"},
+		{"[site](https://example.com) <https://example.com/x>", "[site|https://example.com] [https://example.com/x]"},
+		{"![](image.png)", "!image.png!"},
+		{"> quoted", "bq. quoted"},
+		{"- one\n  - two\n1. three", "* one\n** two\n# three"},
+		{"---", "----"},
+		{"| a | b |\n| --- | --- |\n| 1 | 2 |", "||a||b||\n|1|2|"},
+		{"```go\nx := *y*\n```", "{code:go}\nx := *y*\n{code}"},
+		{"```\nunterminated", "{code}\nunterminated\n{code}"},
+	} {
+		assert.Equal(t, test.wiki, MarkdownToWiki(test.markdown), test.markdown)
+	}
+}
+
+func TestWikiToMarkdown(t *testing.T) {
+	for _, test := range []struct {
+		wiki     string
+		markdown string
+	}{
+		{"h2. Title", "## Title"},
+		{"*bold* and _italic_ and -gone-", "**bold** and *italic* and ~~gone~~"},
+		{"snake_case_name and a-b-c", "snake_case_name and a-b-c"},
+		{"{{mono}}", "`mono`"},
+		{"[site|https://example.com] [https://example.com/x]", "[site](https://example.com) <https://example.com/x>"},
+		{"!image.png|thumbnail!", "![](image.png)"},
+		{"bq. quoted", "> quoted"},
+		{"{quote}\nline\n{quote}", "> line"},
+		{"* one\n** two\n# three", "- one\n  - two\n1. three"},
+		{"----", "---"},
+		{"||a||b||\n|1|2|", "| a | b |\n| --- | --- |\n| 1 | 2 |"},
+		{"{code:language=go}\nx := *y*\n{code}", "```go\nx := *y*\n```"},
+		{"{noformat}one line{noformat}", "```\none line\n```"},
+		{"a\r\nb", "a\nb"},
+	} {
+		assert.Equal(t, test.markdown, WikiToMarkdown(test.wiki), test.wiki)
+	}
+}
+
+func TestMarkdownInput(t *testing.T) {
+	opts := &CommonOptions{}
+	opts.Markdown.Value = true
+	wiki := "h1. Title\n{color:red}kept{color}"
+	input, toWiki, err := opts.markdownInput(map[string]interface{}{
+		"fields": map[string]interface{}{"description": wiki, "summary": "*not converted*"},
+	})
+	assert.NoError(t, err)
+	fields := input.(map[string]interface{})["fields"].(map[string]interface{})
+	assert.Equal(t, "# Title\n{color:red}kept{color}", fields["description"])
+	assert.Equal(t, "*not converted*", fields["summary"])
+
+	// unchanged Markdown keeps the original wiki markup
+	assert.Equal(t, wiki, toWiki(fields["description"].(string)+"\n"))
+	assert.Equal(t, "h1. New", toWiki("# New"))
+
+	opts.Markdown.Value = false
+	input, toWiki, err = opts.markdownInput("same")
+	assert.NoError(t, err)
+	assert.Equal(t, "same", input)
+	assert.Nil(t, toWiki)
+}
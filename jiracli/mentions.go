@@ -0,0 +1,262 @@
+package jiracli
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiradata"
+	"golang.org/x/crypto/ssh/terminal"
+	"gopkg.in/AlecAivazis/survey.v1"
+)
+
+var (
+	mentionPattern        = regexp.MustCompile(`(^|[\s(])@([\w][\w.\-]*[\w])`)
+	mentionWikiPattern    = regexp.MustCompile(`\[~([^\]]+)\]`)
+	mentionAccountPattern = regexp.MustCompile(`\[~accountid:([^\]]+)\]`)
+	mentionCodePattern    = regexp.MustCompile("(?s)\\{(?:code|noformat)[^}]*\\}.*?\\{(?:code|noformat)\\}|\\{\\{.*?\\}\\}|```.*?```|`[^`\n]*`")
+)
+
+// mentionResolver expands the @name and [~name] mentions of text fields to
+// the mention syntax of the Jira deployment, and looks up the display names
+// of the account ids used in mentions on Jira Cloud.
+type mentionResolver struct {
+	ua      jira.HttpClient
+	globals *GlobalOptions
+	// mentions maps the lower case names used in mentions to the mention
+	// syntax
+	mentions map[string]string
+	// names maps account ids to display names
+	names map[string]string
+}
+
+// mentions is set up with the client for the command being run.
+var mentions = &mentionResolver{
+	mentions: map[string]string{},
+	names:    map[string]string{},
+}
+
+func (m *mentionResolver) cloud() (bool, error) {
+	if m.globals.JiraDeploymentType.Value == "" {
+		serverInfo, err := jira.ServerInfo(m.ua, m.globals.Endpoint.Value)
+		if err != nil {
+			return false, err
+		}
+		m.globals.JiraDeploymentType.Value = strings.ToLower(serverInfo.DeploymentType)
+	}
+	return m.globals.JiraDeploymentType.Value == CloudDeploymentType, nil
+}
+
+// remember records the user so the mention is expanded and shown without
+// looking up the user again.
+func (m *mentionResolver) remember(name string, user *jiradata.User, mention string) {
+	m.mentions[strings.ToLower(name)] = mention
+	if user.AccountID != "" {
+		m.names[user.AccountID] = user.DisplayName
+	}
+}
+
+func (m *mentionResolver) syntax(user *jiradata.User) string {
+	if user.AccountID == "" {
+		return "[~" + user.Name + "]"
+	}
+	if m.globals.APIVersion.Value == "3" {
+		return "[@" + user.DisplayName + "](accountid:" + user.AccountID + ")"
+	}
+	return "[~accountid:" + user.AccountID + "]"
+}
+
+// lookup returns the mention for the name, asking which user is meant when
+// more than one user matches the name exactly.  An empty mention is returned
+// when no user matches, users only matching part of the name are not used.
+func (m *mentionResolver) lookup(name string) (string, error) {
+	if mention, ok := m.mentions[strings.ToLower(name)]; ok {
+		return mention, nil
+	}
+	if m.ua == nil {
+		return "", fmt.Errorf("Unable to look up user %q", name)
+	}
+	cloud, err := m.cloud()
+	if err != nil {
+		return "", err
+	}
+	search := &jira.UserSearchOptions{Query: name}
+	if !cloud {
+		search = &jira.UserSearchOptions{Username: name}
+	}
+	users, err := jira.UserSearch(m.ua, m.globals.Endpoint.Value, search)
+	if err != nil {
+		return "", err
+	}
+	matches := []*jiradata.User{}
+	for _, user := range users {
+		for _, value := range []string{user.Name, user.DisplayName, user.EmailAddress, user.AccountID} {
+			if value != "" && strings.EqualFold(value, name) {
+				matches = append(matches, user)
+				break
+			}
+		}
+	}
+	var user *jiradata.User
+	switch {
+	case len(matches) == 0:
+		return "", nil
+	case len(matches) == 1:
+		user = matches[0]
+	case !terminal.IsTerminal(int(os.Stdin.Fd())):
+		return "", fmt.Errorf("Found %d users for mention %q, use a more specific name", len(matches), name)
+	default:
+		options := []string{}
+		for _, match := range matches {
+			option := match.DisplayName
+			if match.EmailAddress != "" {
+				option += " <" + match.EmailAddress + ">"
+			}
+			if match.Name != "" {
+				option += " (" + match.Name + ")"
+			} else {
+				option += " (" + match.AccountID + ")"
+			}
+			options = append(options, option)
+		}
+		choice := ""
+		err := survey.AskOne(&survey.Select{
+			Message: fmt.Sprintf("Which user is mentioned by %q?", name),
+			Options: options,
+		}, &choice, nil)
+		if err != nil {
+			return "", err
+		}
+		for i, option := range options {
+			if option == choice {
+				user = matches[i]
+			}
+		}
+	}
+	mention := m.syntax(user)
+	m.remember(name, user, mention)
+	return mention, nil
+}
+
+// expand replaces the mentions in the text, mentions in code are left alone.
+func (m *mentionResolver) expand(text string) (string, error) {
+	text, restoreCode := protect(text, mentionCodePattern, func(match []string) string {
+		return match[0]
+	})
+	var err error
+	// @name mentions are only expanded when they match a user exactly and
+	// are otherwise left alone as they are likely not meant to be mentions,
+	// like "@Override"
+	replace := func(pattern *regexp.Regexp, strict bool, mention func([]string) (string, string)) {
+		text = pattern.ReplaceAllStringFunc(text, func(match string) string {
+			prefix, name := mention(pattern.FindStringSubmatch(match))
+			if err != nil || name == "" {
+				return match
+			}
+			resolved, lookupErr := m.lookup(name)
+			if lookupErr != nil {
+				if strict {
+					err = lookupErr
+				} else {
+					log.Warningf("Leaving %q unchanged: %s", match, lookupErr)
+				}
+				return match
+			}
+			if resolved == "" {
+				if strict {
+					err = fmt.Errorf("No user found for mention %q", name)
+				}
+				return match
+			}
+			return prefix + resolved
+		})
+	}
+	replace(mentionWikiPattern, true, func(match []string) (string, string) {
+		if strings.HasPrefix(match[1], "accountid:") {
+			return "", ""
+		}
+		// [~name] is the mention syntax of Jira Server
+		if m.ua != nil {
+			if cloud, cloudErr := m.cloud(); cloudErr != nil || !cloud {
+				err = cloudErr
+				return "", ""
+			}
+		}
+		return "", match[1]
+	})
+	replace(mentionPattern, false, func(match []string) (string, string) {
+		return match[1], match[2]
+	})
+	return restoreCode(text), err
+}
+
+// expandFields expands the mentions in the text fields of the data.
+func (m *mentionResolver) expandFields(data interface{}) error {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if text, ok := value.(string); ok && markdownFields[key] {
+				expanded, err := m.expand(text)
+				if err != nil {
+					return err
+				}
+				v[key] = expanded
+			} else if err := m.expandFields(value); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, value := range v {
+			if err := m.expandFields(value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// name returns the display name for the account id, or the account id when
+// the user cannot be found.
+func (m *mentionResolver) name(accountID string, users map[string]string) string {
+	if name, ok := users[accountID]; ok && name != "" {
+		return name
+	}
+	if name, ok := m.names[accountID]; ok {
+		return name
+	}
+	if m.ua == nil {
+		return accountID
+	}
+	found, err := jira.UserSearch(m.ua, m.globals.Endpoint.Value, &jira.UserSearchOptions{AccountID: accountID})
+	if err != nil || len(found) == 0 || found[0].DisplayName == "" {
+		m.names[accountID] = accountID
+		return accountID
+	}
+	m.names[accountID] = found[0].DisplayName
+	return found[0].DisplayName
+}
+
+// mentionNames replaces the account ids of the Jira Cloud mentions in the
+// text with display names, like [~Jane Doe], which are expanded back to the
+// same account ids when the text is submitted.
+func (m *mentionResolver) mentionNames(text string, users map[string]string) string {
+	if adfMarkdown {
+		// the mentions of ADF fields already have the display names
+		return text
+	}
+	return mentionAccountPattern.ReplaceAllStringFunc(text, func(match string) string {
+		accountID := mentionAccountPattern.FindStringSubmatch(match)[1]
+		name := m.name(accountID, users)
+		if name == accountID {
+			return match
+		}
+		if mention, ok := m.mentions[strings.ToLower(name)]; ok && mention != match {
+			// the name is used for another account
+			return match
+		}
+		m.mentions[strings.ToLower(name)] = match
+		return "[~" + name + "]"
+	})
+}
@@ -0,0 +1,64 @@
+package jiracli
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMentionExpand(t *testing.T) {
+	users := map[string]string{
+		"jane":     `[{"accountId":"1","name":"jane","displayName":"Jane Doe"}]`,
+		"janet":    `[{"accountId":"2","displayName":"Janet Roe"}]`,
+		"sam":      `[{"accountId":"3","displayName":"Sam"},{"accountId":"4","displayName":"Sam"}]`,
+		"jane doe": `[{"accountId":"1","displayName":"Jane Doe"}]`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		found, ok := users[strings.ToLower(r.URL.Query().Get("query"))]
+		if !ok {
+			found = "[]"
+		}
+		fmt.Fprint(w, found)
+	}))
+	defer server.Close()
+
+	m := &mentionResolver{
+		ua: oreo.New(),
+		globals: &GlobalOptions{
+			Endpoint:           figtree.NewStringOption(server.URL),
+			JiraDeploymentType: figtree.NewStringOption(CloudDeploymentType),
+		},
+		mentions: map[string]string{},
+		names:    map[string]string{},
+	}
+	for _, test := range []struct {
+		text     string
+		expanded string
+		err      string
+	}{
+		{"hi @jane", "hi [~accountid:1]", ""},
+		{"@jane.doe @Override", "@jane.doe @Override", ""},
+		// only part of the name matches
+		{"cc @janet", "cc @janet", ""},
+		// more than one user matches
+		{"cc @sam", "cc @sam", ""},
+		{"`@jane` {{@jane}}", "`@jane` {{@jane}}", ""},
+		{"[~Jane Doe] [~accountid:9]", "[~accountid:1] [~accountid:9]", ""},
+		{"[~nobody]", "[~nobody]", `No user found for mention "nobody"`},
+		{"[~sam]", "[~sam]", `Found 2 users for mention "sam", use a more specific name`},
+	} {
+		expanded, err := m.expand(test.text)
+		if test.err != "" {
+			assert.EqualError(t, err, test.err, test.text)
+		} else {
+			assert.NoError(t, err, test.text)
+		}
+		assert.Equal(t, test.expanded, expanded, test.text)
+	}
+}
@@ -2,6 +2,7 @@ package jiracli
 
 import (
 	"bytes"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -100,17 +101,7 @@ func TemplateProcessor() *template.Template {
 			return string(bytes), nil
 		},
 		"termWidth": func() int {
-			w, _, err := terminal.GetSize(int(os.Stdout.Fd()))
-			if err == nil {
-				return w
-			}
-			if os.Getenv("COLUMNS") != "" {
-				w, err = strconv.Atoi(os.Getenv("COLUMNS"))
-			}
-			if err == nil {
-				return w
-			}
-			return 120
+			return terminalWidth(os.Stdout)
 		},
 		"pctOf": func(size, percent int) int {
 			return int(float32(size) * (float32(percent) / 100))
@@ -118,6 +109,17 @@ func TemplateProcessor() *template.Template {
 		"sub": func(a, b int) int {
 			return a - b
 		},
+		"scale": func(width int, value, max interface{}) (int, error) {
+			v, err := strconv.ParseFloat(fmt.Sprintf("%v", value), 64)
+			if err != nil {
+				return 0, err
+			}
+			m, err := strconv.ParseFloat(fmt.Sprintf("%v", max), 64)
+			if err != nil || m <= 0 {
+				return 0, err
+			}
+			return int(v / m * float64(width)), nil
+		},
 		"append": func(more string, content interface{}) (string, error) {
 			switch value := content.(type) {
 			case string:
@@ -195,10 +197,94 @@ func TemplateProcessor() *template.Template {
 		"wrap": func(width uint, content string) string {
 			return wordwrap.WrapString(content, width)
 		},
+		"md2jira": func(content interface{}) string {
+			if content == nil {
+				return ""
+			}
+			return MarkdownToWiki(fmt.Sprint(content))
+		},
+		"jira2md": func(content interface{}) string {
+			if content == nil {
+				return ""
+			}
+			return WikiToMarkdown(fmt.Sprint(content))
+		},
+		// the wiki markup is only rendered when RunTemplate writes to a
+		// terminal, otherwise it is passed through
+		"renderWiki": func(content interface{}) string {
+			if content == nil {
+				return ""
+			}
+			return fmt.Sprint(content)
+		},
+		"mentionNames": func(content interface{}) string {
+			if content == nil {
+				return ""
+			}
+			return mentions.mentionNames(fmt.Sprint(content), nil)
+		},
+		"csv": func(values ...interface{}) (string, error) {
+			record := []string{}
+			for _, v := range values {
+				// lists are flattened into multiple columns
+				items, ok := v.([]interface{})
+				if !ok {
+					items = []interface{}{v}
+				}
+				for _, item := range items {
+					if item == nil {
+						record = append(record, "")
+					} else {
+						record = append(record, fmt.Sprintf("%v", item))
+					}
+				}
+			}
+			buf := bytes.NewBufferString("")
+			w := csv.NewWriter(buf)
+			if err := w.Write(record); err != nil {
+				return "", err
+			}
+			w.Flush()
+			return strings.TrimSuffix(buf.String(), "\n"), w.Error()
+		},
 	}
 	return template.New("gojira").Funcs(sprig.GenericFuncMap()).Funcs(funcs)
 }
 
+func terminalWidth(out *os.File) int {
+	w, _, err := terminal.GetSize(int(out.Fd()))
+	if err == nil {
+		return w
+	}
+	if os.Getenv("COLUMNS") != "" {
+		w, err = strconv.Atoi(os.Getenv("COLUMNS"))
+	}
+	if err == nil {
+		return w
+	}
+	return 120
+}
+
+// renderWikiFunc returns the renderWiki template function, which renders wiki
+// markup when the output is a terminal and otherwise returns it unchanged.  A
+// margin is left for the indentation of the rendered text.  The Markdown of ADF
+// fields is converted to wiki markup to be rendered.
+func renderWikiFunc(out io.Writer, users map[string]string) func(interface{}) string {
+	return func(content interface{}) string {
+		if content == nil {
+			return ""
+		}
+		if !isTerminalWriter(out) {
+			return fmt.Sprint(content)
+		}
+		text := fmt.Sprint(content)
+		if adfMarkdown {
+			text = adfWiki(text)
+		}
+		return RenderWiki(text, terminalWidth(out.(*os.File))-4, users)
+	}
+}
+
 func ConfigTemplate(fig *figtree.FigTree, template, command string, opts interface{}) (string, error) {
 	var tmp map[string]interface{}
 	err := ConvertType(opts, &tmp)
@@ -258,11 +344,19 @@ func RunTemplate(templateName string, data interface{}, out io.Writer) error {
 		return err
 	}
 
+	users := templateUsers(rawData)
 	table := tablewriter.NewWriter(out)
 	table.SetAutoFormatHeaders(false)
 	headers := []string{}
 	cells := [][]string{}
 	tmpl, err := TemplateProcessor().Funcs(map[string]interface{}{
+		"renderWiki": renderWikiFunc(out, users),
+		"mentionNames": func(content interface{}) string {
+			if content == nil {
+				return ""
+			}
+			return mentions.mentionNames(fmt.Sprint(content), users)
+		},
 		"headers": func(titles ...string) string {
 			headers = append(headers, titles...)
 			return ""
@@ -296,35 +390,83 @@ func RunTemplate(templateName string, data interface{}, out io.Writer) error {
 }
 
 var AllTemplates = map[string]string{
+	"apply":          defaultApplyTemplate,
 	"attach-list":    defaultAttachListTemplate,
+	"burndown":       defaultBurndownTemplate,
+	"burndown-chart": defaultBurndownChartTemplate,
+	"burndown-csv":   defaultBurndownCSVTemplate,
+	"clone":          defaultCloneTemplate,
 	"comment":        defaultCommentTemplate,
+	"comment-edit":   defaultCommentEditTemplate,
+	"comments":       defaultCommentsTemplate,
 	"component-add":  defaultComponentAddTemplate,
 	"components":     defaultComponentsTemplate,
 	"create":         defaultCreateTemplate,
 	"createmeta":     defaultDebugTemplate,
+	"cycle-time":     defaultCycleTimeTemplate,
+	"cycle-time-csv": defaultCycleTimeCSVTemplate,
 	"debug":          defaultDebugTemplate,
+	"delete":         defaultDeleteTemplate,
 	"edit":           defaultEditTemplate,
 	"editmeta":       defaultDebugTemplate,
 	"epic-create":    defaultEpicCreateTemplate,
 	"epic-list":      defaultTableTemplate,
 	"fields":         defaultDebugTemplate,
+	"graph":          defaultGraphTemplate,
+	"graph-mermaid":  defaultGraphMermaidTemplate,
+	"history":        defaultHistoryTemplate,
+	"import":         defaultImportTemplate,
 	"issuelinktypes": defaultDebugTemplate,
 	"issuetypes":     defaultIssuetypesTemplate,
 	"json":           defaultDebugTemplate,
+	"link-list":      defaultLinkListTemplate,
 	"list":           defaultListTemplate,
+	"move":           defaultMoveTemplate,
+	"properties":     defaultPropertiesTemplate,
+	"property":       defaultPropertyTemplate,
+	"remotelinks":    defaultRemoteLinksTemplate,
+	"reparent":       defaultReparentTemplate,
 	"request":        defaultDebugTemplate,
 	"subtask":        defaultSubtaskTemplate,
 	"table":          defaultTableTemplate,
+	"timer":          defaultTimerTemplate,
+	"timesheet":      defaultTimesheetTemplate,
+	"timesheet-csv":  defaultTimesheetCSVTemplate,
 	"transition":     defaultTransitionTemplate,
 	"transitions":    defaultTransitionsTemplate,
 	"transmeta":      defaultDebugTemplate,
+	"tree":           defaultTreeTemplate,
+	"user":           defaultUserTemplate,
+	"users":          defaultUsersTemplate,
+	"velocity":       defaultVelocityTemplate,
+	"velocity-chart": defaultVelocityChartTemplate,
+	"velocity-csv":   defaultVelocityCSVTemplate,
 	"view":           defaultViewTemplate,
 	"worklog":        defaultWorklogTemplate,
+	"worklog-import": defaultWorklogImportTemplate,
 	"worklogs":       defaultWorklogsTemplate,
 }
 
 const defaultDebugTemplate = "{{ . | toJson}}\n"
 
+const defaultApplyTemplate = `{{/* apply template */ -}}
+{{ range .actions -}}
+{{ if eq .action "create" -}}
++ create {{ .id }}: {{ .summary }}
+{{ else if eq .action "update" -}}
+~ update {{ .key }} ({{ .id }})
+{{ range .changes }}    {{ .field }}: {{ abbrev 60 .from }} -> {{ abbrev 60 .to }}
+{{ end -}}
+{{ else if eq .action "transition" -}}
+> transition {{ or .key .id }}: {{ or .from "new" }} -> {{ .to }}
+{{ else if eq .action "link" -}}
++ link {{ or .key .id }} {{ .link }}
+{{ else if eq .action "unsupported" -}}
+! skip {{ .key }} ({{ .id }}) issuetype: {{ .from }} -> {{ .to }} needs "jira move {{ .key }} --issuetype '{{ .to }}'"
+{{ end -}}
+{{ end -}}
+`
+
 const defaultListTemplate = "{{ range .issues }}{{ .key | append \":\" | printf \"%-12s\"}} {{ .fields.summary }}\n{{ end }}"
 
 const defaultTableTemplate = `{{/* table template */ -}}
@@ -366,6 +508,66 @@ const defaultAttachListTemplate = `{{/* attach list template */ -}}
 {{- end -}}
 `
 
+const defaultLinkListTemplate = `{{/* link list template */ -}}
+{{ range .groups -}}
+{{ .type }} ({{ .label }}):
+{{ range .issues }}  {{ .key | append ":" | printf "%-12s" }} [{{ .status }}] {{ .summary }}
+{{ end -}}
+{{ end -}}
+`
+
+const defaultPropertiesTemplate = `{{/* properties template */ -}}
+{{ range . }}{{ .key }}
+{{ end }}`
+
+const defaultPropertyTemplate = `{{/* property template */ -}}
+{{ .value | toJson }}
+`
+
+const defaultMoveTemplate = `{{/* move template */ -}}
+{{ range .issues -}}
+{{ if $.dryrun -}}
+{{ .from }} would be {{ if eq .method "edit" }}changed to {{ .issuetype }}{{ else }}copied to {{ .project }} as {{ .issuetype }}, linked and closed{{ end }}
+{{ else if .error -}}
+FAILED {{ .from }}: {{ .error }}
+{{ range .copies }}  copied {{ .from }} -> {{ .to }} {{ .url }}
+{{ end -}}
+{{ range .closed }}  closed {{ . }}
+{{ end -}}
+{{ else -}}
+OK {{ .from }} -> {{ .to }} {{ .url }}
+{{ end -}}
+{{ if .subtasks }}  subtasks: {{ if eq .method "edit" }}{{ join ", " .subtasks }} stay with {{ .from }}{{ else }}{{ join ", " .subtasks }} {{ if $.dryrun }}would be {{ end }}copied with {{ .from }}{{ end }}
+{{ end -}}
+{{ if .lost }}  fields lost: {{ join ", " .lost }}
+{{ end -}}
+{{ end -}}
+`
+
+const defaultReparentTemplate = `{{/* reparent template */ -}}
+{{ if .dryrun -}}
+{{ .from }} would be {{ if eq .method "edit" }}changed{{ else }}copied, linked to the copy and closed{{ end }}: {{ if .parent }}subtask of {{ .parent }}{{ else }}standard issue{{ end }}, issue type {{ .issuetype }}
+{{ else -}}
+OK {{ .from }}{{ if ne .from .to }} -> {{ .to }}{{ end }} {{ .url }}
+{{ if eq .method "clone" }}  {{ .from }} could not be changed in place, it was copied to {{ .to }}, linked to the copy and closed
+{{ end -}}
+{{ end -}}
+{{ if .lost }}  fields lost: {{ join ", " .lost }}
+{{ end -}}
+`
+
+const defaultRemoteLinksTemplate = `{{/* remotelinks template */ -}}
+{{- headers "id" "title" "url" "relationship" "application" -}}
+{{- range . -}}
+  {{- row -}}
+  {{- cell .id -}}
+  {{- cell .object.title -}}
+  {{- cell .object.url -}}
+  {{- cell (.relationship | default "") -}}
+  {{- cell (.application.name | default "") -}}
+{{- end -}}
+`
+
 const defaultViewTemplate = `{{/* view template */ -}}
 issue: {{ .key }}
 {{if .fields.created -}}
@@ -403,11 +605,11 @@ votes: {{ .fields.votes.votes}}
 labels: {{ join ", " .fields.labels }}
 {{end -}}
 description: |
-  {{ or .fields.description "" | indent 2 }}
+  {{ or .fields.description "" | renderWiki | indent 2 }}
 {{if .fields.comment.comments}}
 comments:
 {{ range .fields.comment.comments }}  - | # {{.author.displayName}}, {{.created | age}} ago
-    {{ or .body "" | indent 4}}
+    {{ or .body "" | renderWiki | indent 4}}
 {{end}}
 {{end -}}
 `
@@ -446,7 +648,7 @@ fields:
   priority: # Values: {{ range .meta.fields.priority.allowedValues }}{{.name}}, {{end}}
     name: {{ or .overrides.priority .fields.priority.name "" }}{{end}}
   description: |~
-    {{ or .overrides.description .fields.description "" | indent 4 }}
+    {{ or .overrides.description .fields.description "" | mentionNames | indent 4 }}
 # votes: {{ .fields.votes.votes }}
 # comments:
 # {{ range .fields.comment.comments }}  - | # {{.author.displayName}}, {{.created | age}} ago
@@ -543,10 +745,27 @@ fields:
   parent:
     key: {{ .parent.key }}`
 
+const defaultCloneTemplate = `{{/* clone template */ -}}
+{{ range .issues }}OK {{ .from }} -> {{ .to }} {{ .url }}
+{{ end -}}
+`
+
 const defaultCommentTemplate = `body: |~
   {{ or .overrides.comment "" | indent 2 }}
 `
 
+const defaultCommentEditTemplate = `{{/* comment edit template */ -}}
+# issue: {{ .issue }} - comment: {{ .id }}{{if .visibility}} - visibility: {{ .visibility.type }}:{{ .visibility.value }}{{end}}
+body: |~
+  {{ or .overrides.comment .body "" | mentionNames | indent 2 }}
+`
+
+const defaultCommentsTemplate = `{{/* comments template */ -}}
+{{ range .comments }}- # {{.id}}: {{.author.displayName}}, {{.created | age}} ago{{if .visibility}} [{{.visibility.type}}: {{.visibility.value}}]{{end}}
+  {{ or .body "" | mentionNames | indent 2 }}
+
+{{end}}`
+
 const defaultTransitionTemplate = `{{/* transition template */ -}}
 {{- if .meta.fields.comment }}
 update:
@@ -573,7 +792,7 @@ fields:
 {{- end -}}
 {{if .meta.fields.description}}
   description: |~
-    {{ or .fields.description "" | indent 4 }}
+    {{ or .fields.description "" | mentionNames | indent 4 }}
 {{- end -}}
 {{if .meta.fields.fixVersions -}}
   {{if .meta.fields.fixVersions.allowedValues}}
@@ -631,6 +850,179 @@ timeSpent: {{ or .timeSpent "" }}
 started: {{ or .started "" }}
 `
 
+const defaultImportTemplate = `{{/* import template */ -}}
+{{- headers "Row" "Ref" "Key" "Status" "Summary" "Error" -}}
+{{- range .issues -}}
+  {{- row -}}
+  {{- cell .row -}}
+  {{- cell .ref -}}
+  {{- cell .key -}}
+  {{- cell .status -}}
+  {{- cell (abbrev 40 .summary) -}}
+  {{- cell .error -}}
+{{- end -}}
+`
+
+const defaultWorklogImportTemplate = `{{/* worklog import template */ -}}
+{{- headers "Line" "Issue" "Started" "Time Spent" "Status" "Comment" -}}
+{{- range .worklogs -}}
+  {{- row -}}
+  {{- cell .line -}}
+  {{- cell .issue -}}
+  {{- cell .started -}}
+  {{- cell .timeSpent -}}
+  {{- cell .status -}}
+  {{- cell (abbrev 40 .comment) -}}
+{{- end -}}
+`
+
+const defaultCycleTimeTemplate = `{{/* cycle-time template */ -}}
+{{- headers "Issue" "Status" "Lead Time" "Cycle Time" -}}
+{{- range .statuses }}{{ headers . }}{{ end -}}
+{{- range .issues -}}
+  {{- row -}}
+  {{- cell .key -}}
+  {{- cell .status -}}
+  {{- cell .leadTime -}}
+  {{- cell .cycleTime -}}
+  {{- range .statuses }}{{ cell .time }}{{ end -}}
+{{- end -}}
+{{- range .percentiles -}}
+  {{- row -}}
+  {{- cell (printf "p%v" .percentile) -}}
+  {{- cell "" -}}
+  {{- cell .leadTime -}}
+  {{- cell .cycleTime -}}
+  {{- range $.statuses }}{{ cell "" }}{{ end -}}
+{{- end -}}
+`
+
+const defaultCycleTimeCSVTemplate = `{{/* cycle-time csv template */ -}}
+{{ csv "Issue" "Summary" "Status" "Created" "Started" "Done" "Lead Time (days)" "Cycle Time (days)" .statuses }}
+{{ range .issues -}}
+{{ $days := list }}{{ range .statuses }}{{ $days = push $days .days }}{{ end -}}
+{{ csv .key .summary .status .created .started .done .leadTimeDays .cycleTimeDays $days }}
+{{ end }}`
+
+const defaultVelocityTemplate = `{{/* velocity template */ -}}
+{{- headers "Sprint" "Committed" "Completed" "Issues Committed" "Issues Completed" -}}
+{{- range .sprints -}}
+  {{- row -}}
+  {{- cell .name -}}
+  {{- cell .committed -}}
+  {{- cell .completed -}}
+  {{- cell .committedIssues -}}
+  {{- cell .completedIssues -}}
+{{- end -}}
+{{- row -}}
+{{- cell "Average" -}}
+{{- cell "" -}}
+{{- cell .average -}}
+{{- cell "" -}}
+{{- cell "" -}}
+`
+
+const defaultVelocityChartTemplate = `{{/* velocity chart template */ -}}
+{{ $width := sub termWidth 40 -}}
+{{ range .sprints -}}
+{{ printf "%-24.24s" .name }} committed {{ rep (scale $width .committed $.max) "=" }} {{ .committed }}
+{{ printf "%-24s" "" }} completed {{ rep (scale $width .completed $.max) "#" }} {{ .completed }}
+{{ end -}}
+average completed: {{ .average }}
+`
+
+const defaultVelocityCSVTemplate = `{{/* velocity csv template */ -}}
+{{ csv "Sprint" "Start Date" "Complete Date" "Committed" "Completed" "Issues Committed" "Issues Completed" }}
+{{ range .sprints }}{{ csv .name .startDate .completeDate .committed .completed .committedIssues .completedIssues }}
+{{ end }}`
+
+const defaultBurndownTemplate = `{{/* burndown template */ -}}
+{{- headers "Date" "Remaining" "Scope" "Ideal" -}}
+{{- range .days -}}
+  {{- row -}}
+  {{- cell .date -}}
+  {{- cell .remaining -}}
+  {{- cell .scope -}}
+  {{- cell .ideal -}}
+{{- end -}}
+`
+
+const defaultBurndownChartTemplate = `{{/* burndown chart template */ -}}
+{{ $width := sub termWidth 20 -}}
+{{ .name }} ({{ .committed }} points committed, # remaining, . ideal)
+{{ range .days -}}
+{{ $remaining := scale $width .remaining $.max }}{{ $ideal := scale $width .ideal $.max -}}
+{{ .date }} {{ rep $remaining "#" }}{{ if gt $ideal $remaining }}{{ rep (sub $ideal $remaining) "." }}{{ end }} {{ .remaining }}
+{{ end -}}
+`
+
+const defaultBurndownCSVTemplate = `{{/* burndown csv template */ -}}
+{{ csv "Date" "Remaining" "Scope" "Ideal" }}
+{{ range .days }}{{ csv .date .remaining .scope .ideal }}
+{{ end }}`
+
+const defaultGraphTemplate = `{{/* graph template */ -}}
+digraph issues {
+  node [shape=box, style="rounded,filled"];
+{{ range .nodes }}  "{{ .key }}" [label="{{ .key }}\n{{ abbrev 40 .summary | replace "\\" "\\\\" | replace "\"" "\\\"" }}\n[{{ .status }}]", fillcolor="{{ .color }}"];
+{{ end -}}
+{{ range .edges }}  "{{ .from }}" -> "{{ .to }}" [label="{{ .label }}"{{ if .cycle }}, color="red"{{ end }}];
+{{ end -}}
+}
+`
+
+const defaultGraphMermaidTemplate = `{{/* graph mermaid template */ -}}
+graph TD
+{{- range .nodes }}
+  {{ .id }}["{{ .key }}: {{ abbrev 40 .summary | replace "\"" "#quot;" }}<br/>[{{ .status }}]"]
+{{- end }}
+{{- range .edges }}
+  {{ .fromId }} -- "{{ .label }}" --> {{ .toId }}
+{{- end }}
+  classDef new fill:#dfe1e6
+  classDef indeterminate fill:#deebff
+  classDef done fill:#e3fcef
+{{- range .nodes }}{{ if .category }}
+  class {{ .id }} {{ .category }}
+{{- end }}{{ end }}
+{{- range $i, $e := .edges }}{{ if $e.cycle }}
+  linkStyle {{ $i }} stroke:red
+{{- end }}{{ end }}
+`
+
+const defaultHistoryTemplate = `{{/* history template */ -}}
+{{ range .histories -}}
+# {{ if .author }}{{ .author.displayName }}{{ else }}Anonymous{{ end }}, {{ .created | age }} ago
+{{- range .items }}
+  {{- if .diff }}
+  {{ .field }}:
+    {{ .diff | indent 4 }}
+  {{- else }}
+  {{ .field }}: {{ or .fromString "(none)" }} => {{ or .toString "(none)" }}
+  {{- end }}
+{{- end }}
+
+{{ end -}}`
+
+const defaultTreeTemplate = `{{/* tree template */ -}}
+{{ range .issues -}}
+{{ .prefix }}{{ .key }} [{{ .status }}] {{ .summary }}{{ if .assignee }} ({{ .assignee }}){{ end }} - {{ .done }}/{{ .total }} done
+{{- if .pointsTotal }}, {{ .pointsDone }}/{{ .pointsTotal }} points{{ end }} ({{ .percent }}%)
+{{ end -}}
+`
+
+const defaultDeleteTemplate = `{{/* delete template */ -}}
+{{- headers "Issue" "Type" "Status" "Subtasks" "Summary" -}}
+{{- range .issues -}}
+  {{- row -}}
+  {{- cell .key -}}
+  {{- cell .fields.issuetype.name -}}
+  {{- cell .fields.status.name -}}
+  {{- cell (len (.fields.subtasks | default list)) -}}
+  {{- cell .fields.summary -}}
+{{- end -}}
+`
+
 const defaultWorklogsTemplate = `{{/* worklogs template */ -}}
 {{ range .worklogs }}- # {{.author.displayName}}, {{.created | age}} ago
   comment: {{ or .comment "" }}
@@ -638,3 +1030,73 @@ const defaultWorklogsTemplate = `{{/* worklogs template */ -}}
   timeSpent: {{ .timeSpent }}
 
 {{end}}`
+
+const defaultTimerTemplate = `{{/* timer template */ -}}
+{{ if .issue -}}
+issue: {{ .issue }}
+started: {{ .started }}
+elapsed: {{ .elapsed }}
+logged: {{ .logged }}
+{{ if .comment -}}
+comment: {{ .comment }}
+{{ end -}}
+{{ else -}}
+No timer running
+{{ end -}}
+`
+
+const defaultTimesheetTemplate = `{{/* timesheet template */ -}}
+{{- headers "Issue" "Summary" -}}
+{{- range .days }}{{ headers .label }}{{ end -}}
+{{- headers "Total" -}}
+{{- range .issues -}}
+  {{- row -}}
+  {{- cell .key -}}
+  {{- cell (abbrev 40 .summary) -}}
+  {{- range .days }}{{ cell .timeSpent }}{{ end -}}
+  {{- cell .timeSpent -}}
+{{- end -}}
+{{- row -}}
+{{- cell "Total" -}}
+{{- cell "" -}}
+{{- range .days }}{{ cell .timeSpent }}{{ end -}}
+{{- cell .timeSpent -}}
+`
+
+const defaultTimesheetCSVTemplate = `{{/* timesheet csv template */ -}}
+{{ csv "Date" "Issue" "Summary" "Started" "Seconds" "Time Spent" "Comment" }}
+{{ range .worklogs }}{{ csv .date .issue .summary .started .timeSpentSeconds .timeSpent .comment }}
+{{ end }}`
+
+const defaultUsersTemplate = `{{/* users template */ -}}
+{{- headers "account" "displayName" "email" "active" -}}
+{{- range . -}}
+  {{- row -}}
+  {{- cell (or .accountId .name "") -}}
+  {{- cell (.displayName | default "") -}}
+  {{- cell (.emailAddress | default "") -}}
+  {{- if .active }}{{ cell "yes" }}{{ else }}{{ cell "no" }}{{ end -}}
+{{- end -}}
+`
+
+const defaultUserTemplate = `{{/* user template */ -}}
+{{ if .accountId -}}
+accountId: {{ .accountId }}
+{{ end -}}
+{{ if .name -}}
+name: {{ .name }}
+{{ end -}}
+displayName: {{ .displayName }}
+{{ if .emailAddress -}}
+email: {{ .emailAddress }}
+{{ end -}}
+active: {{ if .active }}true{{ else }}false{{ end }}
+{{ if .timeZone -}}
+timeZone: {{ .timeZone }}
+{{ end -}}
+{{ with .groups }}{{ if .items -}}
+groups:
+{{ range .items }}  - {{ .name }}
+{{ end -}}
+{{ end }}{{ end -}}
+`
@@ -0,0 +1,332 @@
+package jiracli
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/mgutz/ansi"
+	"github.com/olekukonko/tablewriter"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// These routines render Jira wiki markup for the terminal with ANSI escape
+// codes, they are used by the renderWiki template function.
+
+const (
+	ansiBold         = "\x1b[1m"
+	ansiBoldOff      = "\x1b[22m"
+	ansiItalic       = "\x1b[3m"
+	ansiItalicOff    = "\x1b[23m"
+	ansiUnderline    = "\x1b[4m"
+	ansiUnderlineOff = "\x1b[24m"
+	ansiStrike       = "\x1b[9m"
+	ansiStrikeOff    = "\x1b[29m"
+)
+
+var (
+	wikiMentionPattern   = regexp.MustCompile(`\[~([^\]]+)\]`)
+	wikiColorPattern     = regexp.MustCompile(`\{color:([^}]*)\}(.*?)\{color\}`)
+	wikiUnderlinePattern = regexp.MustCompile(`(^|[^\w+])\+(\S(?:[^+]*\S)?)\+($|[^\w+])`)
+	wikiBulletMarkers    = []string{"•", "◦", "▪"}
+	wikiColors           = map[string]bool{
+		"black": true, "red": true, "green": true, "yellow": true,
+		"blue": true, "magenta": true, "cyan": true, "white": true,
+	}
+)
+
+// codeKeywords are highlighted in code blocks, they are shared by the common
+// languages rather than being exact for any one of them.
+var codeKeywords = map[string]bool{}
+
+func init() {
+	for _, keyword := range strings.Fields(`and as async await break case catch class const continue def default defer
+		del do done elif else enum esac except export extends false fi final finally fn for foreach from func function go
+		if implements import in interface is lambda let local match new nil none not null or package pass private
+		protected public raise return self static struct super switch then this throw throws true try type typeof
+		undefined var void while with yield`) {
+		codeKeywords[keyword] = true
+	}
+}
+
+// codeHashComments are the languages using # for comments, other languages
+// use // and /* */, or -- for the codeSQLComments languages.
+var (
+	codeHashComments = map[string]bool{
+		"bash": true, "dockerfile": true, "make": true, "perl": true, "py": true, "python": true,
+		"rb": true, "ruby": true, "sh": true, "shell": true, "toml": true, "yaml": true, "yml": true,
+	}
+	codeSQLComments = map[string]bool{"lua": true, "sql": true}
+	codeTokens      = `("(?:[^"\\]|\\.)*"|'(?:[^'\\]|\\.)*'|` + "`[^`]*`" + `)|\b(\d+(?:\.\d+)?)\b|\b([A-Za-z_]\w*)\b`
+	codePatterns    = map[string]*regexp.Regexp{
+		"#":  regexp.MustCompile(`(#.*$)|` + codeTokens),
+		"--": regexp.MustCompile(`(--.*$)|` + codeTokens),
+		"//": regexp.MustCompile(`(//.*$|/\*.*?\*/)|` + codeTokens),
+	}
+)
+
+// highlightCode colors the comments, strings, numbers and keywords of a line
+// of code.
+func highlightCode(line, lang string) string {
+	pattern := codePatterns["//"]
+	if lang = strings.ToLower(lang); codeHashComments[lang] {
+		pattern = codePatterns["#"]
+	} else if codeSQLComments[lang] {
+		pattern = codePatterns["--"]
+	}
+	return pattern.ReplaceAllStringFunc(line, func(token string) string {
+		m := pattern.FindStringSubmatch(token)
+		switch {
+		case m[1] != "":
+			return ansi.Color(token, "black+h")
+		case m[2] != "":
+			return ansi.Color(token, "green")
+		case m[3] != "":
+			return ansi.Color(token, "magenta")
+		case codeKeywords[m[4]]:
+			return ansi.Color(token, "blue+b")
+		}
+		return token
+	})
+}
+
+// wikiCodeLanguage returns the language from the parameters of a code macro,
+// like "java" or "title=Example|language=java".
+func wikiCodeLanguage(params string) string {
+	lang := ""
+	for _, param := range strings.Split(params, "|") {
+		if kv := strings.SplitN(param, "=", 2); len(kv) == 1 {
+			lang = strings.TrimSpace(kv[0])
+		} else if strings.TrimSpace(kv[0]) == "language" {
+			lang = strings.TrimSpace(kv[1])
+		}
+	}
+	return lang
+}
+
+// wikiMentionName returns the name to show for a user mention, using the
+// display names of the known users when available.
+func wikiMentionName(user string, users map[string]string) string {
+	if strings.HasPrefix(user, "accountid:") {
+		return mentions.name(strings.TrimPrefix(user, "accountid:"), users)
+	}
+	if name, ok := users[user]; ok && name != "" {
+		return name
+	}
+	return user
+}
+
+func renderWikiInline(text string, users map[string]string) string {
+	text, restoreCode := protect(text, wikiMonoPattern, func(m []string) string {
+		return ansi.ColorCode("cyan") + m[1] + ansi.DefaultFG
+	})
+	text, restoreMentions := protect(text, wikiMentionPattern, func(m []string) string {
+		return ansi.ColorCode("blue") + ansiBold + "@" + wikiMentionName(m[1], users) + ansiBoldOff + ansi.DefaultFG
+	})
+	text, restoreImages := protect(text, wikiImagePattern, func(m []string) string {
+		return ansi.Color("[image: "+m[1]+"]", "black+h")
+	})
+	text, restoreLinks := protect(text, wikiLinkPattern, func(m []string) string {
+		return ansiUnderline + m[1] + ansiUnderlineOff + ansi.Color(" ("+m[2]+")", "black+h")
+	})
+	text, restoreURLs := protect(text, wikiURLPattern, func(m []string) string {
+		return ansiUnderline + m[1] + ansiUnderlineOff
+	})
+	text = wikiColorPattern.ReplaceAllStringFunc(text, func(match string) string {
+		m := wikiColorPattern.FindStringSubmatch(match)
+		if color := strings.ToLower(strings.TrimSpace(m[1])); wikiColors[color] {
+			return ansi.ColorCode(color) + m[2] + ansi.DefaultFG
+		}
+		return m[2]
+	})
+	text = replaceRepeat(wikiBoldPattern, text, "${1}"+ansiBold+"${2}"+ansiBoldOff+"${3}")
+	text = replaceRepeat(wikiItalicPattern, text, "${1}"+ansiItalic+"${2}"+ansiItalicOff+"${3}")
+	text = replaceRepeat(wikiStrikePattern, text, "${1}"+ansiStrike+"${2}"+ansiStrikeOff+"${3}")
+	text = replaceRepeat(wikiUnderlinePattern, text, "${1}"+ansiUnderline+"${2}"+ansiUnderlineOff+"${3}")
+	return restoreCode(restoreMentions(restoreImages(restoreLinks(restoreURLs(text)))))
+}
+
+// renderWikiTable renders the rows of a wiki table as a table that fits in
+// the width, wrapping the text of the cells when needed.
+func renderWikiTable(lines []string, width int, users map[string]string) string {
+	rows := [][]string{}
+	columns := 0
+	for _, line := range lines {
+		// keep the separators of links out of the cells
+		line = wikiLinkPattern.ReplaceAllStringFunc(line, func(link string) string {
+			return strings.Replace(link, "|", "\x01", -1)
+		})
+		line = strings.Replace(strings.TrimSpace(line), "||", "|", -1)
+		cells := strings.Split(strings.Trim(line, "|"), "|")
+		for i, cell := range cells {
+			cells[i] = renderWikiInline(strings.TrimSpace(strings.Replace(cell, "\x01", "|", -1)), users)
+		}
+		if len(cells) > columns {
+			columns = len(cells)
+		}
+		rows = append(rows, cells)
+	}
+	for i := range rows {
+		for len(rows[i]) < columns {
+			rows[i] = append(rows[i], "")
+		}
+	}
+
+	buf := bytes.NewBufferString("")
+	table := tablewriter.NewWriter(buf)
+	table.SetAutoFormatHeaders(false)
+	table.SetAutoWrapText(true)
+	colWidth := (width - 3*columns - 1) / columns
+	if colWidth < 10 {
+		colWidth = 10
+	}
+	table.SetColWidth(colWidth)
+	if strings.HasPrefix(strings.TrimSpace(lines[0]), "||") {
+		for i, cell := range rows[0] {
+			rows[0][i] = ansiBold + cell + ansiBoldOff
+		}
+		table.SetHeader(rows[0])
+		rows = rows[1:]
+	}
+	table.AppendBulk(rows)
+	table.Render()
+	return strings.TrimRight(buf.String(), "\n")
+}
+
+// RenderWiki renders Jira wiki markup for a terminal of the width: bold,
+// italic and colored text, highlighted code blocks, bullet lists, tables and
+// user mentions using the display names of the users.
+func RenderWiki(text string, width int, users map[string]string) string {
+	lines := strings.Split(strings.Replace(text, "\r\n", "\n", -1), "\n")
+	out := []string{}
+	gutter := ansi.Color("│ ", "black+h")
+	code, lang := "", ""
+	quote := false
+	counters := []int{}
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		if code != "" {
+			end := wikiCodeEndPattern.FindStringSubmatch(line)
+			if end != nil && end[2] == code {
+				line = end[1]
+			}
+			if end == nil || line != "" {
+				if code == "code" {
+					line = highlightCode(line, lang)
+				}
+				out = append(out, gutter+line)
+			}
+			if end != nil && end[2] == code {
+				code = ""
+			}
+			continue
+		}
+		if m := wikiCodePattern.FindStringSubmatch(line); m != nil {
+			code, lang = m[1], wikiCodeLanguage(m[2])
+			if lang != "" {
+				out = append(out, ansi.Color(lang, "black+h"))
+			}
+			if m[3] != "" {
+				// the code starts on the same line as the macro
+				lines[i] = m[3]
+				i--
+			}
+			continue
+		}
+		if strings.TrimSpace(line) == "{quote}" {
+			quote = !quote
+			continue
+		}
+		prefix := ""
+		if quote {
+			prefix = gutter
+		}
+		if !wikiListPattern.MatchString(line) || wikiRulePattern.MatchString(line) {
+			counters = counters[:0]
+		}
+		if strings.HasPrefix(strings.TrimSpace(line), "|") {
+			table := []string{}
+			for ; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), "|"); i++ {
+				table = append(table, lines[i])
+			}
+			i--
+			for _, row := range strings.Split(renderWikiTable(table, width-tablewriter.DisplayWidth(prefix), users), "\n") {
+				out = append(out, prefix+row)
+			}
+			continue
+		}
+		switch {
+		case wikiRulePattern.MatchString(line):
+			out = append(out, prefix+ansi.Color(strings.Repeat("─", width), "black+h"))
+		case wikiHeadingPattern.MatchString(line):
+			m := wikiHeadingPattern.FindStringSubmatch(line)
+			style := ansiBold
+			if m[1] <= "2" {
+				style += ansiUnderline
+			}
+			out = append(out, prefix+style+renderWikiInline(m[2], users)+ansiUnderlineOff+ansiBoldOff)
+		case wikiQuotePattern.MatchString(line):
+			out = append(out, gutter+renderWikiInline(wikiQuotePattern.FindStringSubmatch(line)[1], users))
+		case wikiListPattern.MatchString(line):
+			m := wikiListPattern.FindStringSubmatch(line)
+			depth := len(m[1])
+			for len(counters) < depth {
+				counters = append(counters, 0)
+			}
+			counters = counters[:depth]
+			marker := wikiBulletMarkers[(depth-1)%len(wikiBulletMarkers)]
+			if strings.HasSuffix(m[1], "#") {
+				// bullets are counted as negative numbers to restart the
+				// numbering after them
+				if counters[depth-1] < 0 {
+					counters[depth-1] = 0
+				}
+				counters[depth-1]++
+				marker = fmt.Sprintf("%d.", counters[depth-1])
+			} else {
+				counters[depth-1] = -1
+			}
+			out = append(out, prefix+strings.Repeat("  ", depth-1)+marker+" "+renderWikiInline(m[2], users))
+		default:
+			out = append(out, prefix+renderWikiInline(line, users))
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+// isTerminalWriter returns true when the output is written to a terminal.
+func isTerminalWriter(out io.Writer) bool {
+	file, ok := out.(*os.File)
+	return ok && terminal.IsTerminal(int(file.Fd()))
+}
+
+// templateUsers returns the display names of the users found in the
+// template data by account id and by name, used to show user mentions.
+func templateUsers(data interface{}) map[string]string {
+	users := map[string]string{}
+	var walk func(interface{})
+	walk = func(data interface{}) {
+		switch value := data.(type) {
+		case map[string]interface{}:
+			if name, ok := value["displayName"].(string); ok {
+				for _, key := range []string{"accountId", "name", "key"} {
+					if id, ok := value[key].(string); ok && id != "" {
+						users[id] = name
+					}
+				}
+			}
+			for _, v := range value {
+				walk(v)
+			}
+		case []interface{}:
+			for _, v := range value {
+				walk(v)
+			}
+		}
+	}
+	walk(data)
+	return users
+}
@@ -0,0 +1,55 @@
+package jiracli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mgutz/ansi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderWiki(t *testing.T) {
+	users := map[string]string{"jdoe": "Jane Doe", "5b10ac8d82e05b22cc7d4ef5": "John Roe"}
+	for _, test := range []struct {
+		wiki     string
+		rendered string
+	}{
+		{"plain text", "plain text"},
+		{"*bold* _italic_ -strike- +under+", ansiBold + "bold" + ansiBoldOff + " " + ansiItalic + "italic" + ansiItalicOff + " " +
+			ansiStrike + "strike" + ansiStrikeOff + " " + ansiUnderline + "under" + ansiUnderlineOff},
+		{"2*3*4 and snake_case_name", "2*3*4 and snake_case_name"},
+		{"h1. Title", ansiBold + ansiUnderline + "Title" + ansiUnderlineOff + ansiBoldOff},
+		{"h3. Sub", ansiBold + "Sub" + ansiUnderlineOff + ansiBoldOff},
+		{"{{*mono*}}", ansi.ColorCode("cyan") + "*mono*" + ansi.DefaultFG},
+		{"{color:red}red{color} {color:#123456}hex{color}", ansi.ColorCode("red") + "red" + ansi.DefaultFG + " hex"},
+		{"[~jdoe] [~accountid:5b10ac8d82e05b22cc7d4ef5] [~other]",
+			ansi.ColorCode("blue") + ansiBold + "@Jane Doe" + ansiBoldOff + ansi.DefaultFG + " " +
+				ansi.ColorCode("blue") + ansiBold + "@John Roe" + ansiBoldOff + ansi.DefaultFG + " " +
+				ansi.ColorCode("blue") + ansiBold + "@other" + ansiBoldOff + ansi.DefaultFG},
+		{"[site|https://example.com]", ansiUnderline + "site" + ansiUnderlineOff + ansi.Color(" (https://example.com)", "black+h")},
+		{"* one\n** two\n# first\n# second\n* bullet\n# again", "• one\n  ◦ two\n1. first\n2. second\n• bullet\n1. again"},
+		{"bq. quoted", ansi.Color("│ ", "black+h") + "quoted"},
+		{"{quote}\nline\n{quote}", ansi.Color("│ ", "black+h") + "line"},
+		{"----", ansi.Color("──────────", "black+h")},
+		{"{noformat}*raw*{noformat}", ansi.Color("│ ", "black+h") + "*raw*"},
+		{"{code:go}\nreturn 1\n{code}", ansi.Color("go", "black+h") + "\n" +
+			ansi.Color("│ ", "black+h") + ansi.Color("return", "blue+b") + " " + ansi.Color("1", "magenta")},
+	} {
+		assert.Equal(t, test.rendered, RenderWiki(test.wiki, 10, users), test.wiki)
+	}
+}
+
+func TestRenderWikiTemplate(t *testing.T) {
+	// the wiki markup is only rendered for a terminal
+	tmpl, err := TemplateProcessor().Parse(`{{ renderWiki .body }}`)
+	assert.NoError(t, err)
+	buf := bytes.NewBufferString("")
+	assert.NoError(t, tmpl.Execute(buf, map[string]interface{}{"body": "*bold*"}))
+	assert.Equal(t, "*bold*", buf.String())
+
+	AllTemplates["render-wiki-test"] = `{{ renderWiki .body }}{{ renderWiki .missing }}`
+	defer delete(AllTemplates, "render-wiki-test")
+	buf.Reset()
+	assert.NoError(t, RunTemplate("render-wiki-test", map[string]interface{}{"body": "h1. Title"}, buf))
+	assert.Equal(t, "h1. Title", buf.String())
+}
@@ -0,0 +1,628 @@
+package jiracmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	"github.com/go-jira/jira/jiradata"
+	"golang.org/x/crypto/ssh/terminal"
+	"gopkg.in/AlecAivazis/survey.v1"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+	yaml "gopkg.in/coryb/yaml.v2"
+)
+
+type ApplyOptions struct {
+	jiracli.CommonOptions `yaml:",inline" json:",inline" figtree:",inline"`
+	Project               string `yaml:"project,omitempty" json:"project,omitempty"`
+	IDPrefix              string `yaml:"id-prefix,omitempty" json:"id-prefix,omitempty"`
+	DryRun                bool   `yaml:"dryrun,omitempty" json:"dryrun,omitempty"`
+	Yes                   bool   `yaml:"yes,omitempty" json:"yes,omitempty"`
+}
+
+func CmdApplyRegistry() *jiracli.CommandRegistryEntry {
+	opts := ApplyOptions{
+		CommonOptions: jiracli.CommonOptions{
+			Template: figtree.NewStringOption("apply"),
+		},
+		IDPrefix: "apply-",
+	}
+
+	return &jiracli.CommandRegistryEntry{
+		"Create and update issues to match a YAML file",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdApplyUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			if opts.File.Value == "" {
+				return fmt.Errorf("--file is required")
+			}
+			return CmdApply(o, globals, &opts)
+		},
+	}
+}
+
+func CmdApplyUsage(cmd *kingpin.CmdClause, opts *ApplyOptions) error {
+	jiracli.TemplateUsage(cmd, &opts.CommonOptions)
+	cmd.Flag("file", "YAML file describing the issues, or - for stdin").Short('f').SetValue(&opts.File)
+	cmd.Flag("project", "Project of the issues when the file does not set one").Short('p').StringVar(&opts.Project)
+	cmd.Flag("id-prefix", "Prefix of the label storing the id of the issues, defaults to \"apply-\"").StringVar(&opts.IDPrefix)
+	cmd.Flag("dryrun", "Only print the plan").BoolVar(&opts.DryRun)
+	cmd.Flag("yes", "Apply the plan without asking for confirmation").BoolVar(&opts.Yes)
+	return nil
+}
+
+// applySpec is the document read from the file given to apply.
+type applySpec struct {
+	Project string        `yaml:"project,omitempty"`
+	Issues  []*applyIssue `yaml:"issues,omitempty"`
+}
+
+// applyIssue is an issue described by the file.  The ID is stored as a label
+// on the issue to find it again on the next apply.
+type applyIssue struct {
+	ID        string                 `yaml:"id,omitempty"`
+	IssueType string                 `yaml:"issuetype,omitempty"`
+	Summary   string                 `yaml:"summary,omitempty"`
+	Status    string                 `yaml:"status,omitempty"`
+	Labels    []string               `yaml:"labels,omitempty"`
+	Fields    map[string]interface{} `yaml:"fields,omitempty"`
+	Links     []*applyLink           `yaml:"links,omitempty"`
+	Subtasks  []*applyIssue          `yaml:"subtasks,omitempty"`
+	parent    *applyIssue
+	key       string
+	current   *jiradata.Issue
+}
+
+// applyLink links the issue to another issue of the file by id, or to any
+// issue by key.  With a Blocks link type, outward means the issue blocks the
+// other issue and inward means the issue is blocked by the other issue.
+type applyLink struct {
+	Type    string `yaml:"type,omitempty"`
+	Outward string `yaml:"outward,omitempty"`
+	Inward  string `yaml:"inward,omitempty"`
+}
+
+type applyChange struct {
+	Field string `json:"field" yaml:"field"`
+	From  string `json:"from" yaml:"from"`
+	To    string `json:"to" yaml:"to"`
+}
+
+// applyAction is a single step of the plan.  Action is one of "create",
+// "update", "transition" or "link", or "unsupported" for issue type changes
+// that need a move and are only reported.
+type applyAction struct {
+	Action    string         `json:"action" yaml:"action"`
+	ID        string         `json:"id" yaml:"id"`
+	Key       string         `json:"key" yaml:"key"`
+	Summary   string         `json:"summary" yaml:"summary"`
+	Changes   []*applyChange `json:"changes,omitempty" yaml:"changes,omitempty"`
+	From      string         `json:"from,omitempty" yaml:"from,omitempty"`
+	To        string         `json:"to,omitempty" yaml:"to,omitempty"`
+	Link      string         `json:"link,omitempty" yaml:"link,omitempty"`
+	issue     *applyIssue
+	fields    map[string]interface{}
+	request   *jiradata.LinkIssueRequest
+	other     string
+	direction string
+}
+
+// CmdApply will read the issues described by the file, compare them to the
+// issues found by their id label, and send the creates, updates, transitions
+// and links needed to the "apply" template.  The plan is then applied after
+// confirmation.  Applying the same file twice makes no changes.
+func CmdApply(o *oreo.Client, globals *jiracli.GlobalOptions, opts *ApplyOptions) error {
+	spec, err := readApplySpec(opts.File.Value)
+	if err != nil {
+		return err
+	}
+	project := spec.Project
+	if project == "" {
+		project = opts.Project
+	}
+	if project == "" {
+		return fmt.Errorf("project is required, set it in the file or use --project")
+	}
+
+	issues := []*applyIssue{}
+	byID := map[string]*applyIssue{}
+	var walk func([]*applyIssue, *applyIssue) error
+	walk = func(list []*applyIssue, parent *applyIssue) error {
+		for _, issue := range list {
+			if issue.ID == "" {
+				return fmt.Errorf("id is required for the issue %q", issue.Summary)
+			}
+			if strings.ContainsAny(issue.ID, " \t\n") {
+				return fmt.Errorf("id %q cannot contain spaces, it is stored as a label", issue.ID)
+			}
+			if _, ok := byID[issue.ID]; ok {
+				return fmt.Errorf("id %q is used more than once", issue.ID)
+			}
+			if issue.Summary == "" {
+				return fmt.Errorf("summary is required for %s", issue.ID)
+			}
+			issue.parent = parent
+			if issue.IssueType == "" {
+				if parent != nil {
+					issue.IssueType = "Sub-task"
+				} else {
+					issue.IssueType = "Task"
+				}
+			}
+			byID[issue.ID] = issue
+			issues = append(issues, issue)
+			if err := walk(issue.Subtasks, issue); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := walk(spec.Issues, nil); err != nil {
+		return err
+	}
+	if len(issues) == 0 {
+		return fmt.Errorf("No issues found in %s", opts.File.Value)
+	}
+
+	labels := []string{}
+	for _, issue := range issues {
+		labels = append(labels, fmt.Sprintf("%q", opts.IDPrefix+issue.ID))
+	}
+	results, err := jira.Search(o, globals.Endpoint.Value, &jira.SearchOptions{
+		Query:       fmt.Sprintf("project = %q AND labels in (%s)", project, strings.Join(labels, ", ")),
+		QueryFields: "*all",
+	}, jira.WithAutoPagination())
+	if err != nil {
+		return err
+	}
+	for _, found := range results.Issues {
+		for _, label := range applyStrings(found.Fields["labels"]) {
+			if !strings.HasPrefix(label, opts.IDPrefix) {
+				continue
+			}
+			if issue, ok := byID[strings.TrimPrefix(label, opts.IDPrefix)]; ok {
+				if issue.current != nil {
+					return fmt.Errorf("id %q is used by both %s and %s", issue.ID, issue.key, found.Key)
+				}
+				issue.current = found
+				issue.key = found.Key
+			}
+		}
+	}
+
+	creates, updates, transitions, links := []*applyAction{}, []*applyAction{}, []*applyAction{}, []*applyAction{}
+	// unsupported changes are reported in the plan but not applied
+	unsupported := []*applyAction{}
+	for _, issue := range issues {
+		fields := map[string]interface{}{}
+		for name, value := range issue.Fields {
+			fields[name] = value
+		}
+		fields["summary"] = issue.Summary
+		if issue.Labels != nil || issue.current == nil {
+			fields["labels"] = applyLabels(issue, opts.IDPrefix)
+		}
+		if issue.current == nil {
+			fields["project"] = map[string]interface{}{"key": project}
+			fields["issuetype"] = map[string]interface{}{"name": issue.IssueType}
+			creates = append(creates, &applyAction{
+				Action:  "create",
+				ID:      issue.ID,
+				Summary: issue.Summary,
+				issue:   issue,
+				fields:  fields,
+			})
+			if issue.Status != "" {
+				transitions = append(transitions, &applyAction{
+					Action: "transition",
+					ID:     issue.ID,
+					To:     issue.Status,
+					issue:  issue,
+				})
+			}
+		} else {
+			update := &applyAction{
+				Action:  "update",
+				ID:      issue.ID,
+				Key:     issue.key,
+				Summary: issue.Summary,
+				issue:   issue,
+				fields:  map[string]interface{}{},
+			}
+			names := []string{}
+			for name := range fields {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				current := issue.current.Fields[name]
+				if name == "labels" {
+					if applyEqualSets(applyStrings(current), applyStrings(fields[name])) {
+						continue
+					}
+				} else if applyMatches(fields[name], current) {
+					continue
+				}
+				update.fields[name] = fields[name]
+				update.Changes = append(update.Changes, &applyChange{
+					Field: name,
+					From:  applyDisplay(applySubset(fields[name], current)),
+					To:    applyDisplay(fields[name]),
+				})
+			}
+			if !strings.EqualFold(issueTypeName(issue.current), issue.IssueType) {
+				// most configurations only allow changing the issue type
+				// with a move, so only edit it when the edit screen allows
+				editMeta, err := jira.GetIssueEditMeta(o, globals.Endpoint.Value, issue.key)
+				if err != nil {
+					return err
+				}
+				if name := editableIssueType(editMeta.Fields, issue.IssueType); name != "" {
+					update.fields["issuetype"] = map[string]interface{}{"name": name}
+					update.Changes = append(update.Changes, &applyChange{
+						Field: "issuetype",
+						From:  issueTypeName(issue.current),
+						To:    name,
+					})
+				} else {
+					unsupported = append(unsupported, &applyAction{
+						Action:  "unsupported",
+						ID:      issue.ID,
+						Key:     issue.key,
+						Summary: issue.Summary,
+						From:    issueTypeName(issue.current),
+						To:      issue.IssueType,
+						issue:   issue,
+					})
+				}
+			}
+			if len(update.Changes) > 0 {
+				updates = append(updates, update)
+			}
+			status := issueStatusName(issue.current)
+			if issue.Status != "" && !strings.EqualFold(status, issue.Status) {
+				transitions = append(transitions, &applyAction{
+					Action:  "transition",
+					ID:      issue.ID,
+					Key:     issue.key,
+					Summary: issue.Summary,
+					From:    status,
+					To:      issue.Status,
+					issue:   issue,
+				})
+			}
+		}
+
+		for _, link := range issue.Links {
+			action, err := applyLinkAction(issue, link, byID, project)
+			if err != nil {
+				return err
+			}
+			if action != nil {
+				links = append(links, action)
+			}
+		}
+	}
+	// subtasks are transitioned before their parents so parents can be closed
+	for i, j := 0, len(transitions)-1; i < j; i, j = i+1, j-1 {
+		transitions[i], transitions[j] = transitions[j], transitions[i]
+	}
+
+	actions := append(append(append(creates, updates...), transitions...), links...)
+	if err := opts.PrintTemplate(struct {
+		Actions []*applyAction `json:"actions" yaml:"actions"`
+	}{append(append([]*applyAction{}, actions...), unsupported...)}); err != nil {
+		return err
+	}
+	if opts.DryRun {
+		return nil
+	}
+	if len(actions) == 0 {
+		if !globals.Quiet.Value {
+			fmt.Println("OK No changes to apply")
+		}
+		return nil
+	}
+	if !opts.Yes {
+		if !terminal.IsTerminal(int(os.Stdin.Fd())) || opts.File.Value == "-" {
+			return fmt.Errorf("Refusing to apply %d changes without confirmation, use --yes", len(actions))
+		}
+		answer := false
+		err := survey.AskOne(
+			&survey.Confirm{
+				Message: fmt.Sprintf("Apply %d changes?", len(actions)),
+				Default: false,
+			},
+			&answer,
+			nil,
+		)
+		if err != nil {
+			return err
+		}
+		if !answer {
+			panic(jiracli.Exit{1})
+		}
+	}
+
+	for _, action := range actions {
+		if err := runApplyAction(o, globals.Endpoint.Value, action, byID); err != nil {
+			return fmt.Errorf("Failed to %s %s: %s", action.Action, action.ID, err)
+		}
+		if !globals.Quiet.Value {
+			fmt.Printf("OK %s %s %s %s\n", action.Action, action.ID, action.issue.key, jira.URLJoin(globals.Endpoint.Value, "browse", action.issue.key))
+		}
+	}
+	return nil
+}
+
+func readApplySpec(file string) (*applySpec, error) {
+	var data []byte
+	var err error
+	if file == "-" {
+		data, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		data, err = ioutil.ReadFile(file)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// parse maps as map[string]interface{} so field values can be sent as JSON
+	defer func(mapType, iface reflect.Type) {
+		yaml.DefaultMapType = mapType
+		yaml.IfaceType = iface
+	}(yaml.DefaultMapType, yaml.IfaceType)
+	yaml.DefaultMapType = reflect.TypeOf(map[string]interface{}{})
+	yaml.IfaceType = yaml.DefaultMapType.Elem()
+
+	spec := &applySpec{}
+	if err := yaml.Unmarshal(data, spec); err != nil {
+		return nil, fmt.Errorf("Invalid YAML in %s: %s", file, err)
+	}
+	return spec, nil
+}
+
+// applyLinkAction returns the action creating the link, or nil when the
+// issues are already linked.
+func applyLinkAction(issue *applyIssue, link *applyLink, byID map[string]*applyIssue, project string) (*applyAction, error) {
+	if link.Type == "" || (link.Outward == "") == (link.Inward == "") {
+		return nil, fmt.Errorf("links of %s need a type and either outward or inward", issue.ID)
+	}
+	other, direction := link.Outward, "outward"
+	if link.Inward != "" {
+		other, direction = link.Inward, "inward"
+	}
+	otherKey := ""
+	if target, ok := byID[other]; ok {
+		otherKey = target.key
+	} else {
+		otherKey = jiracli.FormatIssue(other, project)
+	}
+
+	if issue.current != nil && otherKey != "" {
+		for _, existing := range issueLinks(issue.current) {
+			if existing.Type == nil || !strings.EqualFold(existing.Type.Name, link.Type) {
+				continue
+			}
+			if direction == "outward" && existing.OutwardIssue != nil && existing.OutwardIssue.Key == otherKey {
+				return nil, nil
+			}
+			if direction == "inward" && existing.InwardIssue != nil && existing.InwardIssue.Key == otherKey {
+				return nil, nil
+			}
+		}
+	}
+	return &applyAction{
+		Action:  "link",
+		ID:      issue.ID,
+		Key:     issue.key,
+		Summary: issue.Summary,
+		Link:    fmt.Sprintf("%s %s %s", link.Type, direction, other),
+		issue:   issue,
+		request: &jiradata.LinkIssueRequest{
+			Type: &jiradata.IssueLinkType{Name: link.Type},
+		},
+		other:     other,
+		direction: direction,
+	}, nil
+}
+
+func runApplyAction(o *oreo.Client, endpoint string, action *applyAction, byID map[string]*applyIssue) error {
+	issue := action.issue
+	switch action.Action {
+	case "create":
+		if issue.parent != nil {
+			action.fields["parent"] = map[string]interface{}{"key": issue.parent.key}
+		}
+		resp, err := jira.CreateIssue(o, endpoint, &jiradata.IssueUpdate{Fields: action.fields})
+		if err != nil {
+			return err
+		}
+		issue.key = resp.Key
+	case "update":
+		return jira.EditIssue(o, endpoint, issue.key, &jiradata.IssueUpdate{Fields: action.fields})
+	case "transition":
+		current, err := jira.GetIssue(o, endpoint, issue.key, &jira.IssueOptions{Fields: []string{"status"}})
+		if err != nil {
+			return err
+		}
+		if strings.EqualFold(issueStatusName(current), action.To) {
+			return nil
+		}
+		meta, err := jira.GetIssueTransitions(o, endpoint, issue.key)
+		if err != nil {
+			return err
+		}
+		var transition *jiradata.Transition
+		for _, t := range meta.Transitions {
+			if t.To != nil && strings.EqualFold(t.To.Name, action.To) {
+				transition = t
+				break
+			}
+		}
+		if transition == nil {
+			transition = meta.Transitions.Find(action.To)
+		}
+		if transition == nil {
+			return fmt.Errorf("no transition from %s to %s", issueStatusName(current), action.To)
+		}
+		return jira.TransitionIssue(o, endpoint, issue.key, &jiradata.IssueUpdate{
+			Transition: &jiradata.Transition{ID: transition.ID},
+		})
+	case "link":
+		otherKey := action.other
+		if target, ok := byID[action.other]; ok {
+			otherKey = target.key
+		}
+		// the inward issue of the request is the one doing the outward action
+		if action.direction == "outward" {
+			action.request.InwardIssue = &jiradata.IssueRef{Key: issue.key}
+			action.request.OutwardIssue = &jiradata.IssueRef{Key: otherKey}
+		} else {
+			action.request.InwardIssue = &jiradata.IssueRef{Key: otherKey}
+			action.request.OutwardIssue = &jiradata.IssueRef{Key: issue.key}
+		}
+		return jira.LinkIssues(o, endpoint, action.request)
+	}
+	return nil
+}
+
+// applyLabels returns the labels of the issue along with its id label.
+func applyLabels(issue *applyIssue, prefix string) []interface{} {
+	labels := []interface{}{}
+	for _, label := range issue.Labels {
+		if label != prefix+issue.ID {
+			labels = append(labels, label)
+		}
+	}
+	return append(labels, prefix+issue.ID)
+}
+
+func applyStrings(value interface{}) []string {
+	strs := []string{}
+	if list, ok := value.([]interface{}); ok {
+		for _, item := range list {
+			strs = append(strs, fmt.Sprint(item))
+		}
+	}
+	return strs
+}
+
+func applyEqualSets(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sort.Strings(a)
+	sort.Strings(b)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// applyMatches returns true when the current value has all the values of the
+// desired value, so {name: High} matches a priority with an id and icon.
+func applyMatches(desired, current interface{}) bool {
+	switch d := desired.(type) {
+	case map[string]interface{}:
+		c, ok := current.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for key, value := range d {
+			if !applyMatches(value, c[key]) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		c, ok := current.([]interface{})
+		if !ok {
+			return len(d) == 0 && current == nil
+		}
+		if len(c) != len(d) {
+			return false
+		}
+		for _, want := range d {
+			found := false
+			for _, have := range c {
+				if applyMatches(want, have) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+		return true
+	case nil:
+		return current == nil
+	}
+	if current == nil {
+		current = ""
+	}
+	return strings.TrimSpace(fmt.Sprint(desired)) == strings.TrimSpace(fmt.Sprint(current))
+}
+
+// applySubset returns the parts of the current value that are set in the
+// desired value, to show only what the file manages in the plan.
+func applySubset(desired, current interface{}) interface{} {
+	switch d := desired.(type) {
+	case map[string]interface{}:
+		if c, ok := current.(map[string]interface{}); ok {
+			subset := map[string]interface{}{}
+			for key, value := range d {
+				subset[key] = applySubset(value, c[key])
+			}
+			return subset
+		}
+	case []interface{}:
+		if c, ok := current.([]interface{}); ok && len(d) > 0 {
+			subset := []interface{}{}
+			for _, item := range c {
+				subset = append(subset, applySubset(d[0], item))
+			}
+			return subset
+		}
+	}
+	return current
+}
+
+func applyDisplay(value interface{}) string {
+	if value == nil {
+		return "null"
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(encoded)
+}
+
+func issueStatusName(issue *jiradata.Issue) string {
+	if status, ok := issue.Fields["status"].(map[string]interface{}); ok {
+		if name, ok := status["name"].(string); ok {
+			return name
+		}
+	}
+	return ""
+}
+
+func issueLinks(issue *jiradata.Issue) []*issueLink {
+	links := []*issueLink{}
+	jiracli.ConvertType(issue.Fields["issuelinks"], &links)
+	return links
+}
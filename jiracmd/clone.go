@@ -0,0 +1,396 @@
+package jiracmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	"github.com/go-jira/jira/jiradata"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type CloneOptions struct {
+	jiracli.CommonOptions `yaml:",inline" json:",inline" figtree:",inline"`
+	Project               string `yaml:"project,omitempty" json:"project,omitempty"`
+	Issue                 string `yaml:"issue,omitempty" json:"issue,omitempty"`
+	TargetProject         string `yaml:"target-project,omitempty" json:"target-project,omitempty"`
+	WithSubtasks          bool   `yaml:"with-subtasks,omitempty" json:"with-subtasks,omitempty"`
+	WithLinks             bool   `yaml:"with-links,omitempty" json:"with-links,omitempty"`
+	WithAttachments       bool   `yaml:"with-attachments,omitempty" json:"with-attachments,omitempty"`
+	SummaryPrefix         string `yaml:"summary-prefix,omitempty" json:"summary-prefix,omitempty"`
+}
+
+func CmdCloneRegistry() *jiracli.CommandRegistryEntry {
+	opts := CloneOptions{
+		CommonOptions: jiracli.CommonOptions{
+			Template: figtree.NewStringOption("clone"),
+		},
+	}
+
+	return &jiracli.CommandRegistryEntry{
+		"Copy an issue with its subtasks, links and attachments",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdCloneUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			opts.Issue = jiracli.FormatIssue(opts.Issue, opts.Project)
+			return CmdClone(o, globals, &opts)
+		},
+	}
+}
+
+func CmdCloneUsage(cmd *kingpin.CmdClause, opts *CloneOptions) error {
+	jiracli.BrowseUsage(cmd, &opts.CommonOptions)
+	jiracli.TemplateUsage(cmd, &opts.CommonOptions)
+	jiracli.GJsonQueryUsage(cmd, &opts.CommonOptions)
+	cmd.Flag("project", "Project to create the copy in, defaults to the project of the issue").StringVar(&opts.TargetProject)
+	cmd.Flag("with-subtasks", "Copy the subtasks under the new issue").BoolVar(&opts.WithSubtasks)
+	cmd.Flag("with-links", "Copy the issue links").BoolVar(&opts.WithLinks)
+	cmd.Flag("with-attachments", "Copy the attachments").BoolVar(&opts.WithAttachments)
+	cmd.Flag("summary-prefix", "Prefix for the summary of the copies, ie: \"CLONE - \"").StringVar(&opts.SummaryPrefix)
+	cmd.Arg("ISSUE", "issue to copy").Required().StringVar(&opts.Issue)
+	return nil
+}
+
+// clonedIssue maps an issue to its copy
+type clonedIssue struct {
+	From string `json:"from" yaml:"from"`
+	To   string `json:"to" yaml:"to"`
+	URL  string `json:"url" yaml:"url"`
+}
+
+type cloneResult struct {
+	Issues []*clonedIssue `json:"issues" yaml:"issues"`
+}
+
+// CmdClone will copy the issue, and optionally its subtasks, links and
+// attachments, then send the mapping from old to new keys to the "clone"
+// template.
+func CmdClone(o *oreo.Client, globals *jiracli.GlobalOptions, opts *CloneOptions) error {
+	cloner := newIssueCloner(o, globals.Endpoint.Value, opts)
+	issue, err := jira.GetIssue(o, globals.Endpoint.Value, opts.Issue, nil)
+	if err != nil {
+		return err
+	}
+	if _, err := cloner.cloneTree(issue, opts.TargetProject, "", issueParentKey(issue)); err != nil {
+		// report the copies made so far, nothing is rolled back
+		if len(cloner.result.Issues) > 0 && !globals.Quiet.Value {
+			opts.PrintTemplate(cloner.result)
+		}
+		return fmt.Errorf("Failed to clone %s: %s", issue.Key, err)
+	}
+
+	if !globals.Quiet.Value {
+		if err := opts.PrintTemplate(cloner.result); err != nil {
+			return err
+		}
+	}
+	if opts.Browse.Value {
+		return CmdBrowse(globals, cloner.result.Issues[0].To)
+	}
+	return nil
+}
+
+// issueCloner copies issues, keeping track of the copies so links between
+// copied issues point to the copies.
+type issueCloner struct {
+	o        *oreo.Client
+	endpoint string
+	opts     *CloneOptions
+	meta     map[string]*jiradata.IssueType
+	keys     map[string]string
+	result   *cloneResult
+}
+
+func newIssueCloner(o *oreo.Client, endpoint string, opts *CloneOptions) *issueCloner {
+	return &issueCloner{
+		o:        o,
+		endpoint: endpoint,
+		opts:     opts,
+		meta:     map[string]*jiradata.IssueType{},
+		keys:     map[string]string{},
+		result:   &cloneResult{},
+	}
+}
+
+// cloneTree copies the issue and, when requested, its subtasks, then
+// recreates the links and attachments of all the copies.  The copy of the
+// issue is created with issueType unless it is empty, under parent unless it
+// is empty.  It returns the key of the copy of the issue.
+func (c *issueCloner) cloneTree(issue *jiradata.Issue, project, issueType, parent string) (string, error) {
+	if project == "" {
+		project = issueProjectKey(issue)
+	}
+	sources := []*jiradata.Issue{issue}
+	newKey, err := c.clone(issue, project, issueType, parent)
+	if err != nil {
+		return "", err
+	}
+
+	if c.opts.WithSubtasks {
+		subtasks := jiradata.Issues{}
+		if err := jiracli.ConvertType(issue.Fields["subtasks"], &subtasks); err != nil {
+			return "", err
+		}
+		for _, subtask := range subtasks {
+			source, err := jira.GetIssue(c.o, c.endpoint, subtask.Key, nil)
+			if err != nil {
+				return "", err
+			}
+			if _, err := c.clone(source, project, "", newKey); err != nil {
+				return "", err
+			}
+			sources = append(sources, source)
+		}
+	}
+
+	if c.opts.WithAttachments {
+		for _, source := range sources {
+			if err := c.copyAttachments(source); err != nil {
+				return "", err
+			}
+		}
+	}
+	if c.opts.WithLinks {
+		linked := map[string]bool{}
+		for _, source := range sources {
+			if err := c.copyLinks(source, linked); err != nil {
+				return "", err
+			}
+		}
+	}
+	return newKey, nil
+}
+
+// clone creates the copy of the issue in the project, copying the fields
+// the create screen of the project has for the issue type.  The issue type of
+// the issue is used when issueType is empty.
+func (c *issueCloner) clone(issue *jiradata.Issue, project, issueType, parent string) (string, error) {
+	if issueType == "" {
+		issueType = issueTypeName(issue)
+	}
+	meta, err := c.createMeta(project, issueType)
+	if err != nil {
+		return "", err
+	}
+
+	fields := map[string]interface{}{
+		"project":   map[string]interface{}{"key": project},
+		"issuetype": map[string]interface{}{"name": issueType},
+	}
+	if parent != "" {
+		if mapped, ok := c.keys[parent]; ok {
+			parent = mapped
+		}
+		fields["parent"] = map[string]interface{}{"key": parent}
+	}
+	for name, fieldMeta := range meta.Fields {
+		if _, ok := fields[name]; ok || skipCloneField(name, fieldMeta) {
+			continue
+		}
+		if value := cloneFieldValue(fieldMeta, issue.Fields[name]); value != nil {
+			fields[name] = value
+		}
+	}
+	if summary, ok := fields["summary"].(string); ok {
+		fields["summary"] = c.opts.SummaryPrefix + summary
+	}
+
+	resp, err := jira.CreateIssue(c.o, c.endpoint, &jiradata.IssueUpdate{Fields: fields})
+	if err != nil {
+		return "", err
+	}
+	c.keys[issue.Key] = resp.Key
+	c.result.Issues = append(c.result.Issues, &clonedIssue{
+		From: issue.Key,
+		To:   resp.Key,
+		URL:  jira.URLJoin(c.endpoint, "browse", resp.Key),
+	})
+	return resp.Key, nil
+}
+
+// copyAttachments downloads the attachments of the issue and uploads them to
+// the copy.
+func (c *issueCloner) copyAttachments(issue *jiradata.Issue) error {
+	attachments := jiradata.ListOfAttachment{}
+	if err := jiracli.ConvertType(issue.Fields["attachment"], &attachments); err != nil {
+		return err
+	}
+	for _, attachment := range attachments {
+		content, err := jira.GetAttachmentContent(c.o, attachment)
+		if err != nil {
+			return fmt.Errorf("Failed to download attachment %s of %s: %s", attachment.Filename, issue.Key, err)
+		}
+		_, err = jira.IssueAttachFile(c.o, c.endpoint, c.keys[issue.Key], attachment.Filename, content)
+		content.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyLinks recreates the links of the issue on the copy, links to issues
+// that were copied too point to their copies.  linked holds the ids of the
+// links already copied, a link between two copied issues is seen on both.
+func (c *issueCloner) copyLinks(issue *jiradata.Issue, linked map[string]bool) error {
+	links := []*issueLink{}
+	if err := jiracli.ConvertType(issue.Fields["issuelinks"], &links); err != nil {
+		return err
+	}
+	mapKey := func(key string) string {
+		if mapped, ok := c.keys[key]; ok {
+			return mapped
+		}
+		return key
+	}
+	for _, link := range links {
+		if link.Type == nil || linked[link.ID] {
+			continue
+		}
+		linked[link.ID] = true
+		req := &jiradata.LinkIssueRequest{
+			Type: &jiradata.IssueLinkType{Name: link.Type.Name},
+		}
+		if link.OutwardIssue != nil {
+			req.InwardIssue = &jiradata.IssueRef{Key: c.keys[issue.Key]}
+			req.OutwardIssue = &jiradata.IssueRef{Key: mapKey(link.OutwardIssue.Key)}
+		} else if link.InwardIssue != nil {
+			req.InwardIssue = &jiradata.IssueRef{Key: mapKey(link.InwardIssue.Key)}
+			req.OutwardIssue = &jiradata.IssueRef{Key: c.keys[issue.Key]}
+		} else {
+			continue
+		}
+		if err := jira.LinkIssues(c.o, c.endpoint, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createMeta returns the fields of the create screen of the issue type in the
+// project.
+func (c *issueCloner) createMeta(project, issueType string) (*jiradata.IssueType, error) {
+	key := project + "\x00" + issueType
+	if meta, ok := c.meta[key]; ok {
+		return meta, nil
+	}
+	meta, err := jira.GetIssueCreateMetaIssueType(c.o, c.endpoint, project, issueType)
+	if err != nil {
+		return nil, err
+	}
+	c.meta[key] = meta
+	return meta, nil
+}
+
+func issueTypeName(issue *jiradata.Issue) string {
+	if issueType, ok := issue.Fields["issuetype"].(map[string]interface{}); ok {
+		if name, ok := issueType["name"].(string); ok {
+			return name
+		}
+	}
+	return ""
+}
+
+func issueProjectKey(issue *jiradata.Issue) string {
+	if project, ok := issue.Fields["project"].(map[string]interface{}); ok {
+		if key, ok := project["key"].(string); ok {
+			return key
+		}
+	}
+	return strings.SplitN(issue.Key, "-", 2)[0]
+}
+
+func issueParentKey(issue *jiradata.Issue) string {
+	if parent, ok := issue.Fields["parent"].(map[string]interface{}); ok {
+		if key, ok := parent["key"].(string); ok {
+			return key
+		}
+	}
+	return ""
+}
+
+// skipCloneField returns true for fields that are set separately or cannot
+// be copied: sprints can be closed and the rank is computed by Jira.
+func skipCloneField(name string, meta *jiradata.FieldMeta) bool {
+	switch name {
+	case "project", "issuetype", "parent", "attachment", "issuelinks":
+		return true
+	}
+	if meta.Schema != nil {
+		for _, custom := range []string{"gh-sprint", "gh-lexo-rank", "gh-global-rank"} {
+			if strings.HasSuffix(meta.Schema.Custom, custom) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// cloneFieldValue converts the value of a field of a fetched issue to the
+// value to create an issue with.  Objects are reduced to the property that
+// identifies them, empty values return nil.
+func cloneFieldValue(meta *jiradata.FieldMeta, value interface{}) interface{} {
+	isUser := meta.Schema != nil && (meta.Schema.Type == "user" || meta.Schema.Items == "user")
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		if v == "" {
+			return nil
+		}
+		return v
+	case []interface{}:
+		if len(v) == 0 {
+			return nil
+		}
+		items := []interface{}{}
+		for _, item := range v {
+			if ref, ok := item.(map[string]interface{}); ok {
+				items = append(items, cloneFieldRef(ref, isUser))
+			} else {
+				items = append(items, item)
+			}
+		}
+		return items
+	case map[string]interface{}:
+		if meta.Schema != nil && meta.Schema.System == "timetracking" {
+			tracking := map[string]interface{}{}
+			for _, name := range []string{"originalEstimate", "remainingEstimate"} {
+				if estimate, ok := v[name]; ok {
+					tracking[name] = estimate
+				}
+			}
+			if len(tracking) == 0 {
+				return nil
+			}
+			return tracking
+		}
+		return cloneFieldRef(v, isUser)
+	}
+	return value
+}
+
+// cloneFieldRef reduces an object like a user, option, version or component
+// to the property identifying it.
+func cloneFieldRef(ref map[string]interface{}, isUser bool) map[string]interface{} {
+	names := []string{"id", "key", "name", "value"}
+	if isUser {
+		names = []string{"accountId", "name", "key"}
+	}
+	for _, name := range names {
+		if value, ok := ref[name]; ok {
+			result := map[string]interface{}{name: value}
+			// cascading selects have the selected child option
+			if child, ok := ref["child"].(map[string]interface{}); ok {
+				result["child"] = cloneFieldRef(child, false)
+			}
+			return result
+		}
+	}
+	return ref
+}
@@ -0,0 +1,94 @@
+package jiracmd
+
+import (
+	"testing"
+
+	"github.com/go-jira/jira/jiradata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSkipCloneField(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		meta *jiradata.FieldMeta
+		skip bool
+	}{
+		{"summary", &jiradata.FieldMeta{}, false},
+		{"project", &jiradata.FieldMeta{}, true},
+		{"issuetype", &jiradata.FieldMeta{}, true},
+		{"parent", &jiradata.FieldMeta{}, true},
+		{"attachment", &jiradata.FieldMeta{}, true},
+		{"issuelinks", &jiradata.FieldMeta{}, true},
+		{"customfield_1", &jiradata.FieldMeta{Schema: &jiradata.JSONType{Custom: "com.pyxis.greenhopper.jira:gh-sprint"}}, true},
+		{"customfield_2", &jiradata.FieldMeta{Schema: &jiradata.JSONType{Custom: "com.pyxis.greenhopper.jira:gh-lexo-rank"}}, true},
+		{"customfield_3", &jiradata.FieldMeta{Schema: &jiradata.JSONType{Custom: "com.pyxis.greenhopper.jira:gh-global-rank"}}, true},
+		{"customfield_4", &jiradata.FieldMeta{Schema: &jiradata.JSONType{Custom: "com.atlassian.jira.plugin.system.customfieldtypes:select"}}, false},
+	} {
+		assert.Equal(t, test.skip, skipCloneField(test.name, test.meta), test.name)
+	}
+}
+
+func TestCloneFieldValue(t *testing.T) {
+	user := &jiradata.FieldMeta{Schema: &jiradata.JSONType{Type: "user"}}
+	users := &jiradata.FieldMeta{Schema: &jiradata.JSONType{Type: "array", Items: "user"}}
+	tracking := &jiradata.FieldMeta{Schema: &jiradata.JSONType{Type: "timetracking", System: "timetracking"}}
+	option := &jiradata.FieldMeta{Schema: &jiradata.JSONType{Type: "option"}}
+	for _, test := range []struct {
+		name   string
+		meta   *jiradata.FieldMeta
+		value  interface{}
+		cloned interface{}
+	}{
+		{"nil", &jiradata.FieldMeta{}, nil, nil},
+		{"empty string", &jiradata.FieldMeta{}, "", nil},
+		{"empty list", &jiradata.FieldMeta{}, []interface{}{}, nil},
+		{"string", &jiradata.FieldMeta{}, "text", "text"},
+		{"number", &jiradata.FieldMeta{}, 3.0, 3.0},
+		{"labels", &jiradata.FieldMeta{}, []interface{}{"a", "b"}, []interface{}{"a", "b"}},
+		{"cloud user", user,
+			map[string]interface{}{"accountId": "5b10", "name": "jdoe", "displayName": "Jane Doe"},
+			map[string]interface{}{"accountId": "5b10"}},
+		{"server user", user,
+			map[string]interface{}{"name": "jdoe", "key": "JIRAUSER1", "displayName": "Jane Doe"},
+			map[string]interface{}{"name": "jdoe"}},
+		{"user list", users,
+			[]interface{}{map[string]interface{}{"accountId": "1", "displayName": "A"}, map[string]interface{}{"accountId": "2"}},
+			[]interface{}{map[string]interface{}{"accountId": "1"}, map[string]interface{}{"accountId": "2"}}},
+		{"option", option,
+			map[string]interface{}{"id": "10", "value": "Red", "self": "https://example.com"},
+			map[string]interface{}{"id": "10"}},
+		{"components", &jiradata.FieldMeta{},
+			[]interface{}{map[string]interface{}{"id": "1", "name": "ui"}},
+			[]interface{}{map[string]interface{}{"id": "1"}}},
+		{"cascading select", option,
+			map[string]interface{}{"id": "10", "value": "Car", "child": map[string]interface{}{"id": "11", "value": "Red"}},
+			map[string]interface{}{"id": "10", "child": map[string]interface{}{"id": "11"}}},
+		{"timetracking", tracking,
+			map[string]interface{}{"originalEstimate": "1d", "remainingEstimate": "4h", "timeSpent": "4h", "timeSpentSeconds": 14400.0},
+			map[string]interface{}{"originalEstimate": "1d", "remainingEstimate": "4h"}},
+		{"empty timetracking", tracking, map[string]interface{}{"timeSpent": "1h"}, nil},
+		{"unknown object", &jiradata.FieldMeta{}, map[string]interface{}{"other": 1.0}, map[string]interface{}{"other": 1.0}},
+	} {
+		assert.Equal(t, test.cloned, cloneFieldValue(test.meta, test.value), test.name)
+	}
+}
+
+func TestCloneFieldRef(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		ref    map[string]interface{}
+		isUser bool
+		cloned map[string]interface{}
+	}{
+		{"id first", map[string]interface{}{"id": "1", "key": "K", "name": "n"}, false, map[string]interface{}{"id": "1"}},
+		{"key", map[string]interface{}{"key": "K", "name": "n"}, false, map[string]interface{}{"key": "K"}},
+		{"value", map[string]interface{}{"value": "v"}, false, map[string]interface{}{"value": "v"}},
+		{"user ignores id", map[string]interface{}{"id": "1", "accountId": "a"}, true, map[string]interface{}{"accountId": "a"}},
+		{"user key", map[string]interface{}{"key": "JIRAUSER1"}, true, map[string]interface{}{"key": "JIRAUSER1"}},
+		{"child", map[string]interface{}{"value": "a", "child": map[string]interface{}{"value": "b", "self": "x"}}, false,
+			map[string]interface{}{"value": "a", "child": map[string]interface{}{"value": "b"}}},
+		{"unidentified", map[string]interface{}{"self": "x"}, false, map[string]interface{}{"self": "x"}},
+	} {
+		assert.Equal(t, test.cloned, cloneFieldRef(test.ref, test.isUser), test.name)
+	}
+}
@@ -2,6 +2,7 @@ package jiracmd
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/coryb/figtree"
 	"github.com/coryb/oreo"
@@ -17,6 +18,7 @@ type CommentOptions struct {
 	Project               string            `yaml:"project,omitempty" json:"project,omitempty"`
 	Overrides             map[string]string `yaml:"overrides,omitempty" json:"overrides,omitempty"`
 	Issue                 string            `yaml:"issue,omitempty" json:"issue,omitempty"`
+	Visibility            string            `yaml:"visibility,omitempty" json:"visibility,omitempty"`
 }
 
 func CmdCommentRegistry() *jiracli.CommandRegistryEntry {
@@ -43,25 +45,34 @@ func CmdCommentRegistry() *jiracli.CommandRegistryEntry {
 func CmdCommentUsage(cmd *kingpin.CmdClause, opts *CommentOptions) error {
 	jiracli.BrowseUsage(cmd, &opts.CommonOptions)
 	jiracli.EditorUsage(cmd, &opts.CommonOptions)
+	jiracli.MarkdownUsage(cmd, &opts.CommonOptions)
 	jiracli.TemplateUsage(cmd, &opts.CommonOptions)
 	cmd.Flag("noedit", "Disable opening the editor").SetValue(&opts.SkipEditing)
 	cmd.Flag("comment", "Comment message for issue").Short('m').PreAction(func(ctx *kingpin.ParseContext) error {
 		opts.Overrides["comment"] = jiracli.FlagValue(ctx, "comment")
 		return nil
 	}).String()
+	cmd.Flag("visibility", "Restrict comment visibility, ie: role:Developers or group:jira-users").StringVar(&opts.Visibility)
 	cmd.Arg("ISSUE", "issue id to update").StringVar(&opts.Issue)
 	return nil
 }
 
 // CmdComment will update issue with comment
 func CmdComment(o *oreo.Client, globals *jiracli.GlobalOptions, opts *CommentOptions) error {
+	visibility, err := parseVisibility(opts.Visibility)
+	if err != nil {
+		return err
+	}
 	comment := jiradata.Comment{}
 	input := struct {
 		Overrides map[string]string `yaml:"overrides,omitempty" json:"overrides,omitempty"`
 	}{
 		opts.Overrides,
 	}
-	err := jiracli.EditLoop(&opts.CommonOptions, &input, &comment, func() error {
+	err = jiracli.EditLoop(&opts.CommonOptions, &input, &comment, func() error {
+		if visibility != nil {
+			comment.Visibility = visibility
+		}
 		_, err := jira.IssueAddComment(o, globals.Endpoint.Value, opts.Issue, &comment)
 		return err
 	})
@@ -79,3 +90,24 @@ func CmdComment(o *oreo.Client, globals *jiracli.GlobalOptions, opts *CommentOpt
 
 	return nil
 }
+
+// parseVisibility converts a "type:value" string like "role:Developers" or
+// "group:jira-users" to a comment visibility restriction.  An empty string
+// returns nil, meaning the comment is visible to everyone.
+func parseVisibility(visibility string) (*jiradata.Visibility, error) {
+	if visibility == "" {
+		return nil, nil
+	}
+	parts := strings.SplitN(visibility, ":", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return nil, fmt.Errorf("Invalid visibility %q, expected role:NAME or group:NAME", visibility)
+	}
+	kind := strings.ToLower(parts[0])
+	if kind != "role" && kind != "group" {
+		return nil, fmt.Errorf("Invalid visibility type %q, expected \"role\" or \"group\"", parts[0])
+	}
+	return &jiradata.Visibility{
+		Type:  kind,
+		Value: parts[1],
+	}, nil
+}
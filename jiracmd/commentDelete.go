@@ -0,0 +1,51 @@
+package jiracmd
+
+import (
+	"fmt"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type CommentDeleteOptions struct {
+	Project   string `yaml:"project,omitempty" json:"project,omitempty"`
+	Issue     string `yaml:"issue,omitempty" json:"issue,omitempty"`
+	CommentID string `yaml:"comment-id,omitempty" json:"comment-id,omitempty"`
+}
+
+func CmdCommentDeleteRegistry() *jiracli.CommandRegistryEntry {
+	opts := CommentDeleteOptions{}
+
+	return &jiracli.CommandRegistryEntry{
+		"Delete comment from issue",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdCommentDeleteUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			opts.Issue = jiracli.FormatIssue(opts.Issue, opts.Project)
+			return CmdCommentDelete(o, globals, &opts)
+		},
+	}
+}
+
+func CmdCommentDeleteUsage(cmd *kingpin.CmdClause, opts *CommentDeleteOptions) error {
+	cmd.Arg("ISSUE", "issue id of comment").Required().StringVar(&opts.Issue)
+	cmd.Arg("COMMENT-ID", "id of comment to delete").Required().StringVar(&opts.CommentID)
+	return nil
+}
+
+// CmdCommentDelete will remove the given comment from the issue
+func CmdCommentDelete(o *oreo.Client, globals *jiracli.GlobalOptions, opts *CommentDeleteOptions) error {
+	if err := jira.IssueRemoveComment(o, globals.Endpoint.Value, opts.Issue, opts.CommentID); err != nil {
+		return err
+	}
+
+	if !globals.Quiet.Value {
+		fmt.Printf("OK Deleted Comment %s from %s\n", opts.CommentID, opts.Issue)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package jiracmd
+
+import (
+	"fmt"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	"github.com/go-jira/jira/jiradata"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type CommentEditOptions struct {
+	jiracli.CommonOptions `yaml:",inline" json:",inline" figtree:",inline"`
+	Project               string            `yaml:"project,omitempty" json:"project,omitempty"`
+	Overrides             map[string]string `yaml:"overrides,omitempty" json:"overrides,omitempty"`
+	Issue                 string            `yaml:"issue,omitempty" json:"issue,omitempty"`
+	CommentID             string            `yaml:"comment-id,omitempty" json:"comment-id,omitempty"`
+	Visibility            string            `yaml:"visibility,omitempty" json:"visibility,omitempty"`
+}
+
+func CmdCommentEditRegistry() *jiracli.CommandRegistryEntry {
+	opts := CommentEditOptions{
+		CommonOptions: jiracli.CommonOptions{
+			Template: figtree.NewStringOption("comment-edit"),
+		},
+		Overrides: map[string]string{},
+	}
+
+	return &jiracli.CommandRegistryEntry{
+		"Edit comment on issue",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdCommentEditUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			opts.Issue = jiracli.FormatIssue(opts.Issue, opts.Project)
+			return CmdCommentEdit(o, globals, &opts)
+		},
+	}
+}
+
+func CmdCommentEditUsage(cmd *kingpin.CmdClause, opts *CommentEditOptions) error {
+	jiracli.BrowseUsage(cmd, &opts.CommonOptions)
+	jiracli.EditorUsage(cmd, &opts.CommonOptions)
+	jiracli.MarkdownUsage(cmd, &opts.CommonOptions)
+	jiracli.TemplateUsage(cmd, &opts.CommonOptions)
+	cmd.Flag("noedit", "Disable opening the editor").SetValue(&opts.SkipEditing)
+	cmd.Flag("comment", "New comment message").Short('m').PreAction(func(ctx *kingpin.ParseContext) error {
+		opts.Overrides["comment"] = jiracli.FlagValue(ctx, "comment")
+		return nil
+	}).String()
+	cmd.Flag("visibility", "Restrict comment visibility, ie: role:Developers or group:jira-users").StringVar(&opts.Visibility)
+	cmd.Arg("ISSUE", "issue id of comment").Required().StringVar(&opts.Issue)
+	cmd.Arg("COMMENT-ID", "id of comment to edit").Required().StringVar(&opts.CommentID)
+	return nil
+}
+
+// CmdCommentEdit will send the existing comment to the "comment-edit" template
+// for editing, then submit the edited comment to jira.
+func CmdCommentEdit(o *oreo.Client, globals *jiracli.GlobalOptions, opts *CommentEditOptions) error {
+	visibility, err := parseVisibility(opts.Visibility)
+	if err != nil {
+		return err
+	}
+	existing, err := jira.GetIssueCommentByID(o, globals.Endpoint.Value, opts.Issue, opts.CommentID)
+	if err != nil {
+		return err
+	}
+	if visibility == nil {
+		// preserve any existing restriction unless we were asked to change it
+		visibility = existing.Visibility
+	}
+
+	comment := jiradata.Comment{}
+	input := struct {
+		*jiradata.Comment `yaml:",inline"`
+		Issue             string            `yaml:"issue,omitempty" json:"issue,omitempty"`
+		Overrides         map[string]string `yaml:"overrides,omitempty" json:"overrides,omitempty"`
+	}{
+		existing,
+		opts.Issue,
+		opts.Overrides,
+	}
+	err = jiracli.EditLoop(&opts.CommonOptions, &input, &comment, func() error {
+		comment.Visibility = visibility
+		_, err := jira.IssueEditComment(o, globals.Endpoint.Value, opts.Issue, opts.CommentID, &comment)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if !globals.Quiet.Value {
+		fmt.Printf("OK %s %s\n", opts.Issue, jira.URLJoin(globals.Endpoint.Value, "browse", opts.Issue))
+	}
+
+	if opts.Browse.Value {
+		return CmdBrowse(globals, opts.Issue)
+	}
+
+	return nil
+}
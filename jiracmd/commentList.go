@@ -0,0 +1,135 @@
+package jiracmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	"github.com/go-jira/jira/jiradata"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+// jiraTimeFormat is the timestamp format used by the Jira REST API for
+// fields like "created", "updated" and "started"
+const jiraTimeFormat = "2006-01-02T15:04:05.000-0700"
+
+type CommentListOptions struct {
+	jiracli.CommonOptions `yaml:",inline" json:",inline" figtree:",inline"`
+	Project               string `yaml:"project,omitempty" json:"project,omitempty"`
+	Issue                 string `yaml:"issue,omitempty" json:"issue,omitempty"`
+	Since                 string `yaml:"since,omitempty" json:"since,omitempty"`
+	Author                string `yaml:"author,omitempty" json:"author,omitempty"`
+}
+
+func CmdCommentListRegistry() *jiracli.CommandRegistryEntry {
+	opts := CommentListOptions{
+		CommonOptions: jiracli.CommonOptions{
+			Template: figtree.NewStringOption("comments"),
+		},
+	}
+	return &jiracli.CommandRegistryEntry{
+		"Prints the comments for given issue",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdCommentListUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			opts.Issue = jiracli.FormatIssue(opts.Issue, opts.Project)
+			return CmdCommentList(o, globals, &opts)
+		},
+	}
+}
+
+func CmdCommentListUsage(cmd *kingpin.CmdClause, opts *CommentListOptions) error {
+	jiracli.BrowseUsage(cmd, &opts.CommonOptions)
+	jiracli.TemplateUsage(cmd, &opts.CommonOptions)
+	jiracli.GJsonQueryUsage(cmd, &opts.CommonOptions)
+	cmd.Flag("since", "Only show comments created after date (YYYY-MM-DD) or duration ago (ie: 36h, 7d)").StringVar(&opts.Since)
+	cmd.Flag("author", "Only show comments by author (name, email, display name or account id)").StringVar(&opts.Author)
+	cmd.Arg("ISSUE", "issue id to fetch comments").Required().StringVar(&opts.Issue)
+	return nil
+}
+
+// CmdCommentList will get comments for the given issue and send them to the
+// "comments" template
+func CmdCommentList(o *oreo.Client, globals *jiracli.GlobalOptions, opts *CommentListOptions) error {
+	var since time.Time
+	if opts.Since != "" {
+		var err error
+		since, err = parseTimeFlag(opts.Since)
+		if err != nil {
+			return err
+		}
+	}
+
+	data, err := jira.GetIssueComment(o, globals.Endpoint.Value, opts.Issue)
+	if err != nil {
+		return err
+	}
+
+	comments := jiradata.Comments{}
+	for _, comment := range *data {
+		if opts.Author != "" && !userMatches(comment.Author, opts.Author) {
+			continue
+		}
+		if !since.IsZero() {
+			created, err := time.Parse(jiraTimeFormat, comment.Created)
+			if err != nil {
+				return err
+			}
+			if created.Before(since) {
+				continue
+			}
+		}
+		comments = append(comments, comment)
+	}
+
+	if err := opts.PrintTemplate(struct {
+		Comments jiradata.Comments `json:"comments,omitempty" yaml:"comments,omitempty"`
+	}{comments}); err != nil {
+		return err
+	}
+	if opts.Browse.Value {
+		return CmdBrowse(globals, opts.Issue)
+	}
+	return nil
+}
+
+// userMatches returns true if the query matches any of the identifying
+// properties of the user, ignoring case.
+func userMatches(user *jiradata.User, query string) bool {
+	if user == nil {
+		return false
+	}
+	for _, value := range []string{user.Name, user.Key, user.EmailAddress, user.DisplayName, user.AccountID} {
+		if value != "" && strings.EqualFold(value, query) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseTimeFlag converts a date (YYYY-MM-DD), a Jira timestamp or a relative
+// duration (ie: 90m, 36h, 7d) into an absolute time.  Durations are relative to
+// now, so "7d" is the time one week ago.
+func parseTimeFlag(value string) (time.Time, error) {
+	if d, err := time.ParseDuration(value); err == nil {
+		return time.Now().Add(-d), nil
+	}
+	if strings.HasSuffix(value, "d") {
+		if days, err := strconv.Atoi(strings.TrimSuffix(value, "d")); err == nil {
+			return time.Now().AddDate(0, 0, -days), nil
+		}
+	}
+	for _, layout := range []string{"2006-01-02", jiraTimeFormat, time.RFC3339} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("Invalid time %q, expected YYYY-MM-DD or a duration like 36h or 7d", value)
+}
@@ -0,0 +1,32 @@
+package jiracmd
+
+import (
+	"testing"
+
+	"github.com/go-jira/jira/jiradata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseVisibility(t *testing.T) {
+	for _, test := range []struct {
+		visibility string
+		expected   *jiradata.Visibility
+		err        bool
+	}{
+		{"", nil, false},
+		{"role:Developers", &jiradata.Visibility{Type: "role", Value: "Developers"}, false},
+		{"Group:jira-admins", &jiradata.Visibility{Type: "group", Value: "jira-admins"}, false},
+		{"group:a:b", &jiradata.Visibility{Type: "group", Value: "a:b"}, false},
+		{"role", nil, true},
+		{"role:", nil, true},
+		{"user:bob", nil, true},
+	} {
+		visibility, err := parseVisibility(test.visibility)
+		if test.err {
+			assert.Error(t, err, test.visibility)
+			continue
+		}
+		assert.NoError(t, err, test.visibility)
+		assert.Equal(t, test.expected, visibility, test.visibility)
+	}
+}
@@ -47,6 +47,7 @@ func CmdCreateRegistry() *jiracli.CommandRegistryEntry {
 func CmdCreateUsage(cmd *kingpin.CmdClause, opts *CreateOptions) error {
 	jiracli.BrowseUsage(cmd, &opts.CommonOptions)
 	jiracli.EditorUsage(cmd, &opts.CommonOptions)
+	jiracli.MarkdownUsage(cmd, &opts.CommonOptions)
 	jiracli.FileUsage(cmd, &opts.CommonOptions)
 	jiracli.TemplateUsage(cmd, &opts.CommonOptions)
 	cmd.Flag("noedit", "Disable opening the editor").SetValue(&opts.SkipEditing)
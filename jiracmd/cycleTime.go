@@ -0,0 +1,332 @@
+package jiracmd
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	"github.com/go-jira/jira/jiradata"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type CycleTimeOptions struct {
+	jiracli.CommonOptions `yaml:",inline" json:",inline" figtree:",inline"`
+	Query                 string   `yaml:"query,omitempty" json:"query,omitempty"`
+	MaxResults            int      `yaml:"max-results,omitempty" json:"max-results,omitempty"`
+	StartCategories       []string `yaml:"start-categories,omitempty" json:"start-categories,omitempty"`
+	DoneCategories        []string `yaml:"done-categories,omitempty" json:"done-categories,omitempty"`
+	Percentiles           []int    `yaml:"percentiles,omitempty" json:"percentiles,omitempty"`
+}
+
+func CmdCycleTimeRegistry() *jiracli.CommandRegistryEntry {
+	opts := CycleTimeOptions{
+		CommonOptions: jiracli.CommonOptions{
+			Template: figtree.NewStringOption("cycle-time"),
+		},
+	}
+	return &jiracli.CommandRegistryEntry{
+		"Prints time in status, lead time and cycle time for issues",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdCycleTimeUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			if len(opts.StartCategories) == 0 {
+				opts.StartCategories = []string{"indeterminate"}
+			}
+			if len(opts.DoneCategories) == 0 {
+				opts.DoneCategories = []string{"done"}
+			}
+			if len(opts.Percentiles) == 0 {
+				opts.Percentiles = []int{50, 85, 95}
+			}
+			for _, p := range opts.Percentiles {
+				if p <= 0 || p > 100 {
+					return fmt.Errorf("Invalid percentile %d, expected 1 to 100", p)
+				}
+			}
+			return CmdCycleTime(o, globals, &opts)
+		},
+	}
+}
+
+func CmdCycleTimeUsage(cmd *kingpin.CmdClause, opts *CycleTimeOptions) error {
+	jiracli.TemplateUsage(cmd, &opts.CommonOptions)
+	jiracli.GJsonQueryUsage(cmd, &opts.CommonOptions)
+	cmd.Flag("query", "Jira Query Language (JQL) expression for the issues to report").Short('q').Required().StringVar(&opts.Query)
+	cmd.Flag("limit", "Maximum number of issues to report").Short('l').IntVar(&opts.MaxResults)
+	cmd.Flag("start-category", "Status category key or name where cycle time starts, can be repeated, defaults to \"indeterminate\" (In Progress)").StringsVar(&opts.StartCategories)
+	cmd.Flag("done-category", "Status category key or name where lead and cycle time end, can be repeated, defaults to \"done\"").StringsVar(&opts.DoneCategories)
+	cmd.Flag("percentile", "Percentile of lead and cycle time to report, can be repeated, defaults to 50, 85 and 95").IntsVar(&opts.Percentiles)
+	return nil
+}
+
+type cycleTimeStatus struct {
+	Status  string  `json:"status" yaml:"status"`
+	Seconds int     `json:"seconds" yaml:"seconds"`
+	Days    float64 `json:"days,omitempty" yaml:"days,omitempty"`
+	Time    string  `json:"time" yaml:"time"`
+}
+
+type cycleTimeIssue struct {
+	Key              string             `json:"key" yaml:"key"`
+	Summary          string             `json:"summary" yaml:"summary"`
+	Status           string             `json:"status" yaml:"status"`
+	Created          string             `json:"created" yaml:"created"`
+	Started          string             `json:"started" yaml:"started"`
+	Done             string             `json:"done" yaml:"done"`
+	LeadTimeSeconds  int                `json:"leadTimeSeconds" yaml:"leadTimeSeconds"`
+	LeadTimeDays     float64            `json:"leadTimeDays,omitempty" yaml:"leadTimeDays,omitempty"`
+	LeadTime         string             `json:"leadTime" yaml:"leadTime"`
+	CycleTimeSeconds int                `json:"cycleTimeSeconds" yaml:"cycleTimeSeconds"`
+	CycleTimeDays    float64            `json:"cycleTimeDays,omitempty" yaml:"cycleTimeDays,omitempty"`
+	CycleTime        string             `json:"cycleTime" yaml:"cycleTime"`
+	Statuses         []*cycleTimeStatus `json:"statuses" yaml:"statuses"`
+}
+
+type cycleTimePercentile struct {
+	Percentile       int     `json:"percentile" yaml:"percentile"`
+	LeadTimeSeconds  int     `json:"leadTimeSeconds" yaml:"leadTimeSeconds"`
+	LeadTimeDays     float64 `json:"leadTimeDays,omitempty" yaml:"leadTimeDays,omitempty"`
+	LeadTime         string  `json:"leadTime" yaml:"leadTime"`
+	CycleTimeSeconds int     `json:"cycleTimeSeconds" yaml:"cycleTimeSeconds"`
+	CycleTimeDays    float64 `json:"cycleTimeDays,omitempty" yaml:"cycleTimeDays,omitempty"`
+	CycleTime        string  `json:"cycleTime" yaml:"cycleTime"`
+}
+
+type cycleTimeReport struct {
+	Statuses    []string               `json:"statuses" yaml:"statuses"`
+	Issues      []*cycleTimeIssue      `json:"issues" yaml:"issues"`
+	Percentiles []*cycleTimePercentile `json:"percentiles" yaml:"percentiles"`
+}
+
+// CmdCycleTime will replay the status changes in the changelog of each issue
+// matching the query to compute the time spent in each status, the lead time
+// (created until done) and the cycle time (started until done), then send the
+// report to the "cycle-time" template.
+func CmdCycleTime(o *oreo.Client, globals *jiracli.GlobalOptions, opts *CycleTimeOptions) error {
+	categories, err := getStatusCategories(o, globals.Endpoint.Value)
+	if err != nil {
+		return err
+	}
+	categoryOf := func(id, name string) *jiradata.StatusCategory {
+		if category, ok := categories[id]; ok {
+			return category
+		}
+		return categories[strings.ToLower(name)]
+	}
+
+	results, err := jira.Search(o, globals.Endpoint.Value, &jira.SearchOptions{
+		Query:      opts.Query,
+		MaxResults: opts.MaxResults,
+	}, jira.WithAutoPagination())
+	if err != nil {
+		return err
+	}
+
+	report := &cycleTimeReport{}
+	// rank of the status columns, statuses are ordered by category and then
+	// by the order they are first seen
+	rank := map[string]int{}
+	addStatus := func(name string, category *jiradata.StatusCategory) {
+		if _, ok := rank[name]; !ok {
+			rank[name] = len(rank)
+			if category != nil {
+				rank[name] += 100000 * categoryRank(category.Key)
+			}
+			report.Statuses = append(report.Statuses, name)
+		}
+	}
+
+	now := time.Now()
+	times := map[*cycleTimeIssue]map[string]int{}
+	for _, result := range results.Issues {
+		issue, histories, err := getIssueHistories(o, globals.Endpoint.Value, result.Key, []string{"summary", "status", "created"})
+		if err != nil {
+			return err
+		}
+		created, err := time.Parse(jiraTimeFormat, fmt.Sprintf("%v", issue.Fields["created"]))
+		if err != nil {
+			return err
+		}
+		current := &jiradata.Status{}
+		if err := jiracli.ConvertType(issue.Fields["status"], current); err != nil {
+			return err
+		}
+		summary, _ := issue.Fields["summary"].(string)
+		row := &cycleTimeIssue{
+			Key:     issue.Key,
+			Summary: summary,
+			Status:  current.Name,
+			Created: created.Format(jiraTimeFormat),
+		}
+
+		type change struct {
+			at   time.Time
+			item *jiradata.ChangeItem
+		}
+		changes := []change{}
+		for _, history := range histories {
+			for _, item := range history.Items {
+				if item.Field != "status" {
+					continue
+				}
+				at, err := time.Parse(jiraTimeFormat, history.Created)
+				if err != nil {
+					return err
+				}
+				changes = append(changes, change{at: at, item: item})
+			}
+		}
+		sort.SliceStable(changes, func(i, j int) bool {
+			return changes[i].at.Before(changes[j].at)
+		})
+
+		// replay the status changes starting from the status the issue was
+		// created in
+		status, statusID := current.Name, current.ID
+		if len(changes) > 0 {
+			status, statusID = changes[0].item.FromString, changes[0].item.From
+		}
+		spent := map[string]int{}
+		var started, done time.Time
+		enter := func(at time.Time, id, name string) {
+			category := categoryOf(id, name)
+			if id == current.ID && category == nil {
+				category = current.StatusCategory
+			}
+			addStatus(name, category)
+			if started.IsZero() && categoryMatches(category, opts.StartCategories) {
+				started = at
+			}
+			if categoryMatches(category, opts.DoneCategories) {
+				if done.IsZero() {
+					done = at
+				}
+			} else {
+				// reopened issues are not done
+				done = time.Time{}
+			}
+		}
+		since := created
+		enter(created, statusID, status)
+		for _, c := range changes {
+			spent[status] += int(c.at.Sub(since).Seconds())
+			status, statusID, since = c.item.ToString, c.item.To, c.at
+			enter(c.at, statusID, status)
+		}
+		spent[status] += int(now.Sub(since).Seconds())
+
+		if !started.IsZero() {
+			row.Started = started.Format(jiraTimeFormat)
+		}
+		if !done.IsZero() {
+			row.Done = done.Format(jiraTimeFormat)
+			row.LeadTimeSeconds = int(done.Sub(created).Seconds())
+			row.LeadTimeDays, row.LeadTime = formatDays(row.LeadTimeSeconds)
+			if !started.IsZero() && !started.After(done) {
+				row.CycleTimeSeconds = int(done.Sub(started).Seconds())
+				row.CycleTimeDays, row.CycleTime = formatDays(row.CycleTimeSeconds)
+			}
+		}
+		times[row] = spent
+		report.Issues = append(report.Issues, row)
+	}
+
+	sort.SliceStable(report.Statuses, func(i, j int) bool {
+		return rank[report.Statuses[i]] < rank[report.Statuses[j]]
+	})
+	for _, row := range report.Issues {
+		for _, name := range report.Statuses {
+			status := &cycleTimeStatus{
+				Status:  name,
+				Seconds: times[row][name],
+			}
+			status.Days, status.Time = formatDays(status.Seconds)
+			row.Statuses = append(row.Statuses, status)
+		}
+	}
+
+	leadTimes, cycleTimes := []int{}, []int{}
+	for _, row := range report.Issues {
+		if row.Done != "" {
+			leadTimes = append(leadTimes, row.LeadTimeSeconds)
+		}
+		if row.CycleTime != "" {
+			cycleTimes = append(cycleTimes, row.CycleTimeSeconds)
+		}
+	}
+	for _, p := range opts.Percentiles {
+		percentile := &cycleTimePercentile{
+			Percentile:       p,
+			LeadTimeSeconds:  percentileOf(leadTimes, p),
+			CycleTimeSeconds: percentileOf(cycleTimes, p),
+		}
+		percentile.LeadTimeDays, percentile.LeadTime = formatDays(percentile.LeadTimeSeconds)
+		percentile.CycleTimeDays, percentile.CycleTime = formatDays(percentile.CycleTimeSeconds)
+		report.Percentiles = append(report.Percentiles, percentile)
+	}
+
+	return opts.PrintTemplate(report)
+}
+
+// categoryMatches returns true if the status category key or name is one of
+// the categories.
+func categoryMatches(category *jiradata.StatusCategory, categories []string) bool {
+	if category == nil {
+		return false
+	}
+	for _, c := range categories {
+		for _, name := range strings.Split(c, ",") {
+			name = strings.TrimSpace(name)
+			if strings.EqualFold(name, category.Key) || strings.EqualFold(name, category.Name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// percentileOf returns the nearest-rank percentile of values, or 0 if there
+// are no values.
+func percentileOf(values []int, p int) int {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]int{}, values...)
+	sort.Ints(sorted)
+	rank := int(math.Ceil(float64(p) / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// formatDays returns the seconds as days rounded to two decimals and as a
+// display string like "2.5d", or zero values if seconds is not positive.
+func formatDays(seconds int) (float64, string) {
+	if seconds <= 0 {
+		return 0, ""
+	}
+	days := math.Round(float64(seconds)/86400*100) / 100
+	return days, fmt.Sprintf("%.1fd", days)
+}
+
+// categoryRank orders the builtin status categories by workflow progress.
+func categoryRank(key string) int {
+	switch key {
+	case "new":
+		return 0
+	case "indeterminate":
+		return 1
+	case "done":
+		return 2
+	}
+	return 3
+}
@@ -0,0 +1,45 @@
+package jiracmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPercentileOf(t *testing.T) {
+	values := []int{15, 20, 35, 40, 50}
+	for _, test := range []struct {
+		values   []int
+		p        int
+		expected int
+	}{
+		{nil, 50, 0},
+		{[]int{7}, 85, 7},
+		{values, 0, 15},
+		{values, 30, 20},
+		{values, 40, 20},
+		{values, 50, 35},
+		{values, 100, 50},
+		{[]int{50, 15, 40, 35, 20}, 50, 35},
+	} {
+		assert.Equal(t, test.expected, percentileOf(test.values, test.p), "p%d of %v", test.p, test.values)
+	}
+}
+
+func TestFormatDays(t *testing.T) {
+	for _, test := range []struct {
+		seconds int
+		days    float64
+		display string
+	}{
+		{0, 0, ""},
+		{-10, 0, ""},
+		{86400, 1, "1.0d"},
+		{129600, 1.5, "1.5d"},
+		{3600, 0.04, "0.0d"},
+	} {
+		days, display := formatDays(test.seconds)
+		assert.Equal(t, test.days, days, "%d", test.seconds)
+		assert.Equal(t, test.display, display, "%d", test.seconds)
+	}
+}
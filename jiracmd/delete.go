@@ -0,0 +1,174 @@
+package jiracmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	"github.com/go-jira/jira/jiradata"
+	"golang.org/x/crypto/ssh/terminal"
+	"gopkg.in/AlecAivazis/survey.v1"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type DeleteOptions struct {
+	jiracli.CommonOptions `yaml:",inline" json:",inline" figtree:",inline"`
+	Project               string   `yaml:"project,omitempty" json:"project,omitempty"`
+	Issues                []string `yaml:"issues,omitempty" json:"issues,omitempty"`
+	Query                 string   `yaml:"query,omitempty" json:"query,omitempty"`
+	DeleteSubtasks        bool     `yaml:"delete-subtasks,omitempty" json:"delete-subtasks,omitempty"`
+	Force                 bool     `yaml:"force,omitempty" json:"force,omitempty"`
+}
+
+func CmdDeleteRegistry() *jiracli.CommandRegistryEntry {
+	opts := DeleteOptions{
+		CommonOptions: jiracli.CommonOptions{
+			Template: figtree.NewStringOption("delete"),
+		},
+	}
+
+	return &jiracli.CommandRegistryEntry{
+		"Delete issues",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdDeleteUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			if len(opts.Issues) == 0 && opts.Query == "" {
+				return fmt.Errorf("ISSUE or --query is required")
+			}
+			for i, issue := range opts.Issues {
+				opts.Issues[i] = jiracli.FormatIssue(issue, opts.Project)
+			}
+			return CmdDelete(o, globals, &opts)
+		},
+	}
+}
+
+func CmdDeleteUsage(cmd *kingpin.CmdClause, opts *DeleteOptions) error {
+	jiracli.TemplateUsage(cmd, &opts.CommonOptions)
+	cmd.Flag("query", "Jira Query Language (JQL) expression for the issues to delete").Short('q').StringVar(&opts.Query)
+	cmd.Flag("delete-subtasks", "Delete the subtasks of the issues, issues with subtasks cannot be deleted otherwise").BoolVar(&opts.DeleteSubtasks)
+	cmd.Flag("force", "Delete without confirmation, required when not running interactively").BoolVar(&opts.Force)
+	cmd.Arg("ISSUE", "issues to delete").StringsVar(&opts.Issues)
+	return nil
+}
+
+// CmdDelete will send the issues to delete to the "delete" template, then
+// delete them after the count of issues is typed to confirm.
+func CmdDelete(o *oreo.Client, globals *jiracli.GlobalOptions, opts *DeleteOptions) error {
+	fields := "summary,status,issuetype,subtasks"
+	issues := jiradata.Issues{}
+	if len(opts.Issues) > 0 {
+		for _, key := range opts.Issues {
+			issue, err := jira.GetIssue(o, globals.Endpoint.Value, key, &jira.IssueOptions{
+				Fields: strings.Split(fields, ","),
+			})
+			if err != nil {
+				return err
+			}
+			issues = append(issues, issue)
+		}
+	}
+	if opts.Query != "" {
+		results, err := jira.Search(o, globals.Endpoint.Value, &jira.SearchOptions{
+			Query:       opts.Query,
+			QueryFields: fields,
+		}, jira.WithAutoPagination())
+		if err != nil {
+			return err
+		}
+		issues = append(issues, results.Issues...)
+	}
+
+	// subtasks are deleted with their parent, so they are only counted once
+	seen := map[string]bool{}
+	toDelete := jiradata.Issues{}
+	count := 0
+	withSubtasks := []string{}
+	for _, issue := range issues {
+		if seen[issue.Key] {
+			continue
+		}
+		seen[issue.Key] = true
+		toDelete = append(toDelete, issue)
+		count++
+		subtasks := jiradata.Issues{}
+		if err := jiracli.ConvertType(issue.Fields["subtasks"], &subtasks); err != nil {
+			return err
+		}
+		if len(subtasks) > 0 {
+			withSubtasks = append(withSubtasks, issue.Key)
+		}
+		for _, subtask := range subtasks {
+			if !seen[subtask.Key] {
+				seen[subtask.Key] = true
+				count++
+			}
+		}
+	}
+	if len(withSubtasks) > 0 && !opts.DeleteSubtasks {
+		return fmt.Errorf("Unable to delete %s with subtasks, use --delete-subtasks to delete the subtasks too", strings.Join(withSubtasks, ", "))
+	}
+	if count == 0 {
+		if !globals.Quiet.Value {
+			fmt.Println("OK No issues to delete")
+		}
+		return nil
+	}
+
+	if err := opts.PrintTemplate(struct {
+		Issues jiradata.Issues `json:"issues" yaml:"issues"`
+		Count  int             `json:"count" yaml:"count"`
+	}{toDelete, count}); err != nil {
+		return err
+	}
+
+	if !opts.Force {
+		if !terminal.IsTerminal(int(os.Stdin.Fd())) {
+			return fmt.Errorf("Refusing to delete %d issues without confirmation, use --force", count)
+		}
+		answer := ""
+		err := survey.AskOne(
+			&survey.Input{
+				Message: fmt.Sprintf("Type the number of issues to delete (%d) to confirm:", count),
+			},
+			&answer,
+			nil,
+		)
+		if err != nil {
+			return err
+		}
+		if n, err := strconv.Atoi(strings.TrimSpace(answer)); err != nil || n != count {
+			panic(jiracli.Exit{1})
+		}
+	}
+
+	// issues that were deleted as subtasks of an earlier issue are skipped
+	deleted := map[string]bool{}
+	for _, issue := range toDelete {
+		if deleted[issue.Key] {
+			continue
+		}
+		if err := jira.DeleteIssue(o, globals.Endpoint.Value, issue.Key, opts.DeleteSubtasks); err != nil {
+			return err
+		}
+		deleted[issue.Key] = true
+		subtasks := jiradata.Issues{}
+		if err := jiracli.ConvertType(issue.Fields["subtasks"], &subtasks); err != nil {
+			return err
+		}
+		for _, subtask := range subtasks {
+			deleted[subtask.Key] = true
+		}
+		if !globals.Quiet.Value {
+			fmt.Printf("OK Deleted %s\n", issue.Key)
+		}
+	}
+	return nil
+}
@@ -31,6 +31,7 @@ func CmdEpicCreateRegistry() *jiracli.CommandRegistryEntry {
 func CmdEpicCreateUsage(cmd *kingpin.CmdClause, opts *CreateOptions) error {
 	jiracli.BrowseUsage(cmd, &opts.CommonOptions)
 	jiracli.EditorUsage(cmd, &opts.CommonOptions)
+	jiracli.MarkdownUsage(cmd, &opts.CommonOptions)
 	jiracli.TemplateUsage(cmd, &opts.CommonOptions)
 	cmd.Flag("noedit", "Disable opening the editor").SetValue(&opts.SkipEditing)
 	cmd.Flag("project", "project to create epic in").Short('p').StringVar(&opts.Project)
@@ -0,0 +1,271 @@
+package jiracmd
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	"github.com/go-jira/jira/jiradata"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+const defaultExportFields = "key,issuetype,summary,status,priority,assignee,created,updated"
+
+type ExportOptions struct {
+	jiracli.CommonOptions `yaml:",inline" json:",inline" figtree:",inline"`
+	Query                 string `yaml:"query,omitempty" json:"query,omitempty"`
+	Fields                string `yaml:"fields,omitempty" json:"fields,omitempty"`
+	Format                string `yaml:"format,omitempty" json:"format,omitempty"`
+	MaxResults            int    `yaml:"max-results,omitempty" json:"max-results,omitempty"`
+}
+
+func CmdExportRegistry() *jiracli.CommandRegistryEntry {
+	opts := ExportOptions{
+		Fields: defaultExportFields,
+		Format: "csv",
+	}
+
+	return &jiracli.CommandRegistryEntry{
+		"Export issues as CSV, TSV, Markdown or NDJSON",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdExportUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			return CmdExport(o, globals, &opts)
+		},
+	}
+}
+
+func CmdExportUsage(cmd *kingpin.CmdClause, opts *ExportOptions) error {
+	cmd.Flag("query", "Jira Query Language (JQL) expression for the issues to export").Short('q').Required().StringVar(&opts.Query)
+	cmd.Flag("fields", "Comma separated field ids or names to export, defaults to "+defaultExportFields).StringVar(&opts.Fields)
+	cmd.Flag("format", "Output format: csv, tsv, md or ndjson").EnumVar(&opts.Format, "csv", "tsv", "md", "ndjson")
+	cmd.Flag("limit", "Maximum number of issues to export").Short('l').IntVar(&opts.MaxResults)
+	return nil
+}
+
+// exportColumn is a field to export along with its readable name.
+type exportColumn struct {
+	ID     string
+	Header string
+}
+
+// CmdExport will search for the issues and write the requested fields of each
+// issue to stdout in the requested format.  Field values are flattened to
+// text and custom field ids are replaced by their names in the headers.  The
+// issues are written as each page of results is fetched.
+func CmdExport(o *oreo.Client, globals *jiracli.GlobalOptions, opts *ExportOptions) error {
+	columns, err := exportColumns(o, globals.Endpoint.Value, opts.Fields)
+	if err != nil {
+		return err
+	}
+	ids := []string{}
+	for _, column := range columns {
+		if column.ID != "key" {
+			ids = append(ids, column.ID)
+		}
+	}
+
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+	writer := newExportWriter(out, opts.Format, columns)
+	if err := writer.header(); err != nil {
+		return err
+	}
+	_, err = jira.Search(o, globals.Endpoint.Value, &jira.SearchOptions{
+		Query:       opts.Query,
+		QueryFields: strings.Join(ids, ","),
+		MaxResults:  opts.MaxResults,
+	}, jira.WithPageHandler(func(issues jiradata.Issues) error {
+		for _, issue := range issues {
+			values := []string{}
+			for _, column := range columns {
+				if column.ID == "key" {
+					values = append(values, issue.Key)
+				} else {
+					values = append(values, exportValue(issue.Fields[column.ID]))
+				}
+			}
+			if err := writer.row(values); err != nil {
+				return err
+			}
+		}
+		return out.Flush()
+	}))
+	return err
+}
+
+// exportColumns resolves the comma separated field ids or names to the
+// fields of the server.
+func exportColumns(o *oreo.Client, endpoint, fields string) ([]*exportColumn, error) {
+	all, err := jira.GetFields(o, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	columns := []*exportColumn{}
+	for _, name := range strings.Split(fields, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if strings.EqualFold(name, "key") {
+			columns = append(columns, &exportColumn{ID: "key", Header: "Key"})
+			continue
+		}
+		var found *jiradata.Field
+		for i, field := range all {
+			if field.ID == name {
+				found = &all[i]
+				break
+			}
+			if found == nil && strings.EqualFold(field.Name, name) {
+				found = &all[i]
+			}
+		}
+		if found == nil {
+			return nil, fmt.Errorf("Unknown field %q, use \"jira fields\" to list the fields", name)
+		}
+		columns = append(columns, &exportColumn{ID: found.ID, Header: found.Name})
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("No fields to export")
+	}
+	return columns, nil
+}
+
+// sprintPattern finds the name of a sprint in the text returned by older
+// Jira servers for sprint fields.
+var sprintPattern = regexp.MustCompile(`^com\.atlassian\.greenhopper\.service\.sprint\.Sprint@.*[\[,]name=([^,\]]*)`)
+
+// exportValue flattens a field value to text: users to their display name,
+// statuses and other objects to their name or value, and lists to their items
+// separated by commas.
+func exportValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		if match := sprintPattern.FindStringSubmatch(v); match != nil {
+			return match[1]
+		}
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case []interface{}:
+		items := []string{}
+		for _, item := range v {
+			if text := exportValue(item); text != "" {
+				items = append(items, text)
+			}
+		}
+		return strings.Join(items, ", ")
+	case map[string]interface{}:
+		for _, key := range []string{"displayName", "name", "value", "key"} {
+			if text, ok := v[key].(string); ok && text != "" {
+				if child, ok := v["child"]; ok {
+					// cascading select
+					return text + " / " + exportValue(child)
+				}
+				return text
+			}
+		}
+		if progress, ok := v["percent"]; ok {
+			return exportValue(progress) + "%"
+		}
+		if estimate, ok := v["originalEstimate"].(string); ok {
+			return estimate
+		}
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(encoded)
+}
+
+// exportWriter writes the header and the rows in one of the export formats.
+type exportWriter struct {
+	out     io.Writer
+	csv     *csv.Writer
+	format  string
+	columns []*exportColumn
+}
+
+func newExportWriter(out io.Writer, format string, columns []*exportColumn) *exportWriter {
+	w := &exportWriter{out: out, format: format, columns: columns}
+	switch format {
+	case "csv":
+		w.csv = csv.NewWriter(out)
+	case "tsv":
+		w.csv = csv.NewWriter(out)
+		w.csv.Comma = '\t'
+	}
+	return w
+}
+
+func (w *exportWriter) header() error {
+	headers := []string{}
+	for _, column := range w.columns {
+		headers = append(headers, column.Header)
+	}
+	switch w.format {
+	case "md":
+		if err := w.markdownRow(headers); err != nil {
+			return err
+		}
+		separators := []string{}
+		for range headers {
+			separators = append(separators, "---")
+		}
+		return w.markdownRow(separators)
+	case "ndjson":
+		return nil
+	}
+	w.csv.Write(headers)
+	w.csv.Flush()
+	return w.csv.Error()
+}
+
+func (w *exportWriter) row(values []string) error {
+	switch w.format {
+	case "md":
+		return w.markdownRow(values)
+	case "ndjson":
+		// written by hand to keep the fields in the requested order
+		fields := []string{}
+		for i, column := range w.columns {
+			key, _ := json.Marshal(column.Header)
+			value, _ := json.Marshal(values[i])
+			fields = append(fields, string(key)+":"+string(value))
+		}
+		_, err := fmt.Fprintf(w.out, "{%s}\n", strings.Join(fields, ","))
+		return err
+	}
+	w.csv.Write(values)
+	w.csv.Flush()
+	return w.csv.Error()
+}
+
+var markdownEscaper = strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>")
+
+func (w *exportWriter) markdownRow(values []string) error {
+	cells := []string{}
+	for _, value := range values {
+		cells = append(cells, markdownEscaper.Replace(value))
+	}
+	_, err := fmt.Fprintf(w.out, "| %s |\n", strings.Join(cells, " | "))
+	return err
+}
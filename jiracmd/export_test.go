@@ -0,0 +1,94 @@
+package jiracmd
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/coryb/oreo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportValue(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		value    interface{}
+		expected string
+	}{
+		{"nil", nil, ""},
+		{"string", "text", "text"},
+		{"number", 2.5, "2.5"},
+		{"integer", 3.0, "3"},
+		{"bool", true, "true"},
+		{"user", map[string]interface{}{"accountId": "1", "name": "jdoe", "displayName": "Jane Doe"}, "Jane Doe"},
+		{"status", map[string]interface{}{"id": "3", "name": "In Progress", "statusCategory": map[string]interface{}{"key": "indeterminate"}}, "In Progress"},
+		{"option", map[string]interface{}{"id": "10", "value": "Red"}, "Red"},
+		{"parent", map[string]interface{}{"id": "100", "key": "ABC-1"}, "ABC-1"},
+		{"labels", []interface{}{"a", "b"}, "a, b"},
+		{"components", []interface{}{map[string]interface{}{"name": "API"}, map[string]interface{}{"name": "UI"}}, "API, UI"},
+		{"empty items", []interface{}{"a", nil, ""}, "a"},
+		{"cascading select", map[string]interface{}{"value": "Europe", "child": map[string]interface{}{"value": "France"}}, "Europe / France"},
+		{"progress", map[string]interface{}{"progress": 30.0, "total": 60.0, "percent": 50.0}, "50%"},
+		{"timetracking", map[string]interface{}{"originalEstimate": "1d", "originalEstimateSeconds": 28800.0}, "1d"},
+		{"legacy sprint",
+			[]interface{}{
+				"com.atlassian.greenhopper.service.sprint.Sprint@1a2b[id=1,rapidViewId=2,state=CLOSED,name=Sprint 1,startDate=2020-01-01T00:00:00.000Z]",
+				"com.atlassian.greenhopper.service.sprint.Sprint@3c4d[id=2,rapidViewId=2,state=ACTIVE,name=Sprint 2]",
+			},
+			"Sprint 1, Sprint 2"},
+		{"cloud sprint", []interface{}{map[string]interface{}{"id": 2.0, "name": "Sprint 2", "state": "active"}}, "Sprint 2"},
+		{"unknown object", map[string]interface{}{"id": 1.0}, `{"id":1}`},
+	} {
+		assert.Equal(t, test.expected, exportValue(test.value), test.name)
+	}
+}
+
+func TestExportColumns(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[
+			{"id":"summary","name":"Summary"},
+			{"id":"customfield_10001","name":"Story Points"},
+			{"id":"customfield_10003","name":"customfield_10002"},
+			{"id":"customfield_10002","name":"Team"}
+		]`)
+	}))
+	defer server.Close()
+
+	columns, err := exportColumns(oreo.New(), server.URL, "key, summary,story points,customfield_10002,,Team")
+	assert.NoError(t, err)
+	assert.Equal(t, []*exportColumn{
+		{ID: "key", Header: "Key"},
+		{ID: "summary", Header: "Summary"},
+		{ID: "customfield_10001", Header: "Story Points"},
+		// ids are preferred over names
+		{ID: "customfield_10002", Header: "Team"},
+		{ID: "customfield_10002", Header: "Team"},
+	}, columns)
+
+	_, err = exportColumns(oreo.New(), server.URL, "summary,nope")
+	assert.EqualError(t, err, `Unknown field "nope", use "jira fields" to list the fields`)
+	_, err = exportColumns(oreo.New(), server.URL, " , ")
+	assert.EqualError(t, err, "No fields to export")
+}
+
+func TestExportWriter(t *testing.T) {
+	columns := []*exportColumn{{ID: "key", Header: "Key"}, {ID: "summary", Header: "Summary \"quoted\""}}
+	values := []string{"ABC-1", "a | b\r\nc\nd \"e\""}
+	for _, test := range []struct {
+		format   string
+		expected string
+	}{
+		{"md", "| Key | Summary \"quoted\" |\n| --- | --- |\n| ABC-1 | a \\| b<br>c<br>d \"e\" |\n"},
+		{"ndjson", "{\"Key\":\"ABC-1\",\"Summary \\\"quoted\\\"\":\"a | b\\r\\nc\\nd \\\"e\\\"\"}\n"},
+		{"csv", "Key,\"Summary \"\"quoted\"\"\"\nABC-1,\"a | b\r\nc\nd \"\"e\"\"\"\n"},
+		{"tsv", "Key\t\"Summary \"\"quoted\"\"\"\nABC-1\t\"a | b\r\nc\nd \"\"e\"\"\"\n"},
+	} {
+		out := &bytes.Buffer{}
+		w := newExportWriter(out, test.format, columns)
+		assert.NoError(t, w.header(), test.format)
+		assert.NoError(t, w.row(values), test.format)
+		assert.Equal(t, test.expected, out.String(), test.format)
+	}
+}
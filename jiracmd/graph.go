@@ -0,0 +1,368 @@
+package jiracmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	"github.com/go-jira/jira/jiradata"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type GraphOptions struct {
+	jiracli.CommonOptions `yaml:",inline" json:",inline" figtree:",inline"`
+	Project               string   `yaml:"project,omitempty" json:"project,omitempty"`
+	Issue                 string   `yaml:"issue,omitempty" json:"issue,omitempty"`
+	Query                 string   `yaml:"query,omitempty" json:"query,omitempty"`
+	Depth                 int      `yaml:"depth,omitempty" json:"depth,omitempty"`
+	LinkTypes             []string `yaml:"link-types,omitempty" json:"link-types,omitempty"`
+}
+
+func CmdGraphRegistry() *jiracli.CommandRegistryEntry {
+	opts := GraphOptions{
+		CommonOptions: jiracli.CommonOptions{
+			Template: figtree.NewStringOption("graph"),
+		},
+	}
+	return &jiracli.CommandRegistryEntry{
+		"Prints the graph of linked issues, subtasks and epic children as DOT, Mermaid (-t graph-mermaid) or JSON (-t json)",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdGraphUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			if opts.Issue == "" && opts.Query == "" {
+				return fmt.Errorf("ISSUE or --query is required")
+			}
+			if opts.Issue != "" {
+				opts.Issue = jiracli.FormatIssue(opts.Issue, opts.Project)
+			}
+			if opts.Depth == 0 {
+				opts.Depth = 2
+			}
+			return CmdGraph(o, globals, &opts)
+		},
+	}
+}
+
+func CmdGraphUsage(cmd *kingpin.CmdClause, opts *GraphOptions) error {
+	jiracli.TemplateUsage(cmd, &opts.CommonOptions)
+	jiracli.GJsonQueryUsage(cmd, &opts.CommonOptions)
+	cmd.Flag("query", "Jira Query Language (JQL) expression for the issues to start from").Short('q').StringVar(&opts.Query)
+	cmd.Flag("depth", "Number of links to follow from the starting issues, defaults to 2").IntVar(&opts.Depth)
+	cmd.Flag("link-type", "Only follow links of this type (ie: blocks, subtask, epic), can be repeated").StringsVar(&opts.LinkTypes)
+	cmd.Arg("ISSUE", "issue id to start from").StringVar(&opts.Issue)
+	return nil
+}
+
+// issueLink is an entry of the "issuelinks" field of an issue, only one of
+// InwardIssue and OutwardIssue is set.
+type issueLink struct {
+	ID           string                  `json:"id,omitempty" yaml:"id,omitempty"`
+	Type         *jiradata.IssueLinkType `json:"type,omitempty" yaml:"type,omitempty"`
+	InwardIssue  *jiradata.Issue         `json:"inwardIssue,omitempty" yaml:"inwardIssue,omitempty"`
+	OutwardIssue *jiradata.Issue         `json:"outwardIssue,omitempty" yaml:"outwardIssue,omitempty"`
+}
+
+type graphNode struct {
+	Key       string `json:"key" yaml:"key"`
+	ID        string `json:"id" yaml:"id"`
+	Summary   string `json:"summary" yaml:"summary"`
+	IssueType string `json:"issuetype" yaml:"issuetype"`
+	Status    string `json:"status" yaml:"status"`
+	Category  string `json:"category" yaml:"category"`
+	Color     string `json:"color" yaml:"color"`
+	Depth     int    `json:"depth" yaml:"depth"`
+}
+
+type graphEdge struct {
+	From   string `json:"from" yaml:"from"`
+	FromID string `json:"fromId" yaml:"fromId"`
+	To     string `json:"to" yaml:"to"`
+	ToID   string `json:"toId" yaml:"toId"`
+	Type   string `json:"type" yaml:"type"`
+	Label  string `json:"label" yaml:"label"`
+	Cycle  bool   `json:"cycle" yaml:"cycle"`
+}
+
+type issueGraph struct {
+	Nodes  []*graphNode `json:"nodes" yaml:"nodes"`
+	Edges  []*graphEdge `json:"edges" yaml:"edges"`
+	Cycles [][]string   `json:"cycles" yaml:"cycles"`
+}
+
+// statusCategoryColors are the colors Jira uses for the status categories
+var statusCategoryColors = map[string]string{
+	"new":           "#dfe1e6",
+	"indeterminate": "#deebff",
+	"done":          "#e3fcef",
+}
+
+// CmdGraph will walk the issue links, subtasks and epic children breadth
+// first from the starting issues and send the graph to the "graph" template.
+func CmdGraph(o *oreo.Client, globals *jiracli.GlobalOptions, opts *GraphOptions) error {
+	type queued struct {
+		key   string
+		depth int
+	}
+	queue := []queued{}
+	seen := map[string]bool{}
+	roots := []string{}
+	if opts.Issue != "" {
+		roots = append(roots, opts.Issue)
+	}
+	if opts.Query != "" {
+		results, err := jira.Search(o, globals.Endpoint.Value, &jira.SearchOptions{Query: opts.Query}, jira.WithAutoPagination())
+		if err != nil {
+			return err
+		}
+		for _, issue := range results.Issues {
+			roots = append(roots, issue.Key)
+		}
+	}
+	for _, key := range roots {
+		if !seen[key] {
+			seen[key] = true
+			queue = append(queue, queued{key, 0})
+		}
+	}
+
+	graph := &issueGraph{}
+	nodes := map[string]*graphNode{}
+	edges := map[string]*graphEdge{}
+	// addEdge returns false if the link type is filtered out
+	addEdge := func(from, to, typ, label string) bool {
+		if !opts.followLink(typ, label) {
+			return false
+		}
+		id := strings.Join([]string{from, to, typ}, "\x00")
+		if _, ok := edges[id]; !ok {
+			edge := &graphEdge{From: from, To: to, Type: typ, Label: label}
+			edges[id] = edge
+			graph.Edges = append(graph.Edges, edge)
+		}
+		return true
+	}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		issue, err := jira.GetIssue(o, globals.Endpoint.Value, current.key, &jira.IssueOptions{
+			Fields: []string{"summary", "status", "issuetype", "issuelinks", "subtasks"},
+		})
+		if err != nil {
+			return err
+		}
+		node := newGraphNode(issue)
+		node.Depth = current.depth
+		nodes[node.Key] = node
+		graph.Nodes = append(graph.Nodes, node)
+
+		neighbors := []string{}
+		links := []*issueLink{}
+		if err := jiracli.ConvertType(issue.Fields["issuelinks"], &links); err != nil {
+			return err
+		}
+		for _, link := range links {
+			if link.Type == nil {
+				continue
+			}
+			// edges always point in the outward direction of the link type
+			if link.OutwardIssue != nil {
+				if addEdge(issue.Key, link.OutwardIssue.Key, link.Type.Name, link.Type.Outward) {
+					neighbors = append(neighbors, link.OutwardIssue.Key)
+				}
+			} else if link.InwardIssue != nil {
+				if addEdge(link.InwardIssue.Key, issue.Key, link.Type.Name, link.Type.Outward) {
+					neighbors = append(neighbors, link.InwardIssue.Key)
+				}
+			}
+		}
+
+		subtasks := jiradata.Issues{}
+		if err := jiracli.ConvertType(issue.Fields["subtasks"], &subtasks); err != nil {
+			return err
+		}
+		for _, subtask := range subtasks {
+			if addEdge(issue.Key, subtask.Key, "subtask", "subtask") {
+				neighbors = append(neighbors, subtask.Key)
+			}
+		}
+
+		if strings.EqualFold(node.IssueType, "epic") && opts.followLink("epic", "epic") {
+			results, err := jira.EpicSearch(o, globals.Endpoint.Value, issue.Key, &jira.SearchOptions{
+				Query: "ORDER BY key",
+			}, jira.WithAutoPagination())
+			if err != nil {
+				return err
+			}
+			for _, child := range results.Issues {
+				addEdge(issue.Key, child.Key, "epic", "epic")
+				neighbors = append(neighbors, child.Key)
+			}
+		}
+
+		if current.depth >= opts.Depth {
+			continue
+		}
+		for _, key := range neighbors {
+			if !seen[key] {
+				seen[key] = true
+				queue = append(queue, queued{key, current.depth + 1})
+			}
+		}
+	}
+
+	// drop edges to issues beyond the depth limit
+	kept := []*graphEdge{}
+	for _, edge := range graph.Edges {
+		from, to := nodes[edge.From], nodes[edge.To]
+		if from != nil && to != nil {
+			edge.FromID, edge.ToID = from.ID, to.ID
+			kept = append(kept, edge)
+		}
+	}
+	graph.Edges = kept
+
+	graph.Cycles = blockingCycles(graph.Edges)
+	for _, cycle := range graph.Cycles {
+		log.Warning("Blocking links form a cycle between %s", strings.Join(cycle, ", "))
+	}
+
+	return opts.PrintTemplate(graph)
+}
+
+// followLink returns true if the link type name or description matches the
+// --link-type filters.
+func (o *GraphOptions) followLink(name, label string) bool {
+	if len(o.LinkTypes) == 0 {
+		return true
+	}
+	for _, linkType := range o.LinkTypes {
+		for _, t := range strings.Split(linkType, ",") {
+			t = strings.TrimSpace(t)
+			if strings.EqualFold(t, name) || strings.EqualFold(t, label) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func newGraphNode(issue *jiradata.Issue) *graphNode {
+	node := &graphNode{
+		Key: issue.Key,
+		// DOT and Mermaid identifiers cannot contain "-"
+		ID: strings.Replace(issue.Key, "-", "_", -1),
+	}
+	node.Summary, _ = issue.Fields["summary"].(string)
+	status := &jiradata.Status{}
+	if err := jiracli.ConvertType(issue.Fields["status"], status); err == nil {
+		node.Status = status.Name
+		if status.StatusCategory != nil {
+			node.Category = status.StatusCategory.Key
+		}
+	}
+	issueType := &jiradata.IssueType{}
+	if err := jiracli.ConvertType(issue.Fields["issuetype"], issueType); err == nil {
+		node.IssueType = issueType.Name
+	}
+	node.Color = statusCategoryColors[node.Category]
+	if node.Color == "" {
+		node.Color = "#ffffff"
+	}
+	return node
+}
+
+// isBlockingEdge returns true for links of the builtin "Blocks" link type
+func isBlockingEdge(edge *graphEdge) bool {
+	return strings.EqualFold(edge.Type, "blocks") || strings.EqualFold(edge.Label, "blocks")
+}
+
+// blockingCycles finds the strongly connected components of the blocking
+// links, any component with more than one issue (or an issue blocking itself)
+// is a cycle.  The edges that are part of a cycle are marked.
+func blockingCycles(edges []*graphEdge) [][]string {
+	adjacent := map[string][]string{}
+	keys := []string{}
+	for _, edge := range edges {
+		if !isBlockingEdge(edge) {
+			continue
+		}
+		for _, key := range []string{edge.From, edge.To} {
+			if _, ok := adjacent[key]; !ok {
+				adjacent[key] = []string{}
+				keys = append(keys, key)
+			}
+		}
+		adjacent[edge.From] = append(adjacent[edge.From], edge.To)
+	}
+
+	// Tarjan's strongly connected components algorithm
+	index := map[string]int{}
+	lowlink := map[string]int{}
+	onStack := map[string]bool{}
+	stack := []string{}
+	component := map[string]int{}
+	cycles := [][]string{}
+	var connect func(key string)
+	connect = func(key string) {
+		index[key] = len(index)
+		lowlink[key] = index[key]
+		stack = append(stack, key)
+		onStack[key] = true
+		for _, next := range adjacent[key] {
+			if _, ok := index[next]; !ok {
+				connect(next)
+				if lowlink[next] < lowlink[key] {
+					lowlink[key] = lowlink[next]
+				}
+			} else if onStack[next] && index[next] < lowlink[key] {
+				lowlink[key] = index[next]
+			}
+		}
+		if lowlink[key] != index[key] {
+			return
+		}
+		members := []string{}
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			members = append(members, top)
+			if top == key {
+				break
+			}
+		}
+		selfLoop := false
+		for _, next := range adjacent[key] {
+			if next == key {
+				selfLoop = true
+			}
+		}
+		if len(members) > 1 || selfLoop {
+			sort.Strings(members)
+			for _, member := range members {
+				component[member] = len(cycles) + 1
+			}
+			cycles = append(cycles, members)
+		}
+	}
+	for _, key := range keys {
+		if _, ok := index[key]; !ok {
+			connect(key)
+		}
+	}
+
+	for _, edge := range edges {
+		if isBlockingEdge(edge) && component[edge.From] != 0 && component[edge.From] == component[edge.To] {
+			edge.Cycle = true
+		}
+	}
+	return cycles
+}
@@ -0,0 +1,55 @@
+package jiracmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockingCycles(t *testing.T) {
+	blocks := func(from, to string) *graphEdge {
+		return &graphEdge{From: from, To: to, Type: "Blocks", Label: "blocks"}
+	}
+	for _, test := range []struct {
+		name   string
+		edges  []*graphEdge
+		cycles [][]string
+		marked []bool
+	}{{
+		name:   "no edges",
+		cycles: [][]string{},
+	}, {
+		name:   "chain",
+		edges:  []*graphEdge{blocks("A-1", "A-2"), blocks("A-2", "A-3")},
+		cycles: [][]string{},
+		marked: []bool{false, false},
+	}, {
+		name:   "cycle",
+		edges:  []*graphEdge{blocks("A-1", "A-2"), blocks("A-2", "A-3"), blocks("A-3", "A-1"), blocks("A-3", "A-4")},
+		cycles: [][]string{{"A-1", "A-2", "A-3"}},
+		marked: []bool{true, true, true, false},
+	}, {
+		name:   "self loop",
+		edges:  []*graphEdge{blocks("A-1", "A-1")},
+		cycles: [][]string{{"A-1"}},
+		marked: []bool{true},
+	}, {
+		name:   "two cycles",
+		edges:  []*graphEdge{blocks("A-1", "A-2"), blocks("A-2", "A-1"), blocks("B-1", "B-2"), blocks("B-2", "B-1")},
+		cycles: [][]string{{"A-1", "A-2"}, {"B-1", "B-2"}},
+		marked: []bool{true, true, true, true},
+	}, {
+		name: "other link types are ignored",
+		edges: []*graphEdge{
+			blocks("A-1", "A-2"),
+			{From: "A-2", To: "A-1", Type: "Relates", Label: "relates to"},
+		},
+		cycles: [][]string{},
+		marked: []bool{false, false},
+	}} {
+		assert.Equal(t, test.cycles, blockingCycles(test.edges), test.name)
+		for i, edge := range test.edges {
+			assert.Equal(t, test.marked[i], edge.Cycle, "%s: %s -> %s", test.name, edge.From, edge.To)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package jiracmd
+
+import (
+	"fmt"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type GroupUsersOptions struct {
+	jiracli.CommonOptions `yaml:",inline" json:",inline" figtree:",inline"`
+	Group                 string   `yaml:"group,omitempty" json:"group,omitempty"`
+	Users                 []string `yaml:"users,omitempty" json:"users,omitempty"`
+}
+
+func CmdGroupAddRegistry() *jiracli.CommandRegistryEntry {
+	opts := GroupUsersOptions{}
+
+	return &jiracli.CommandRegistryEntry{
+		"Add users to a group",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdGroupUsersUsage(cmd, &opts, "add to")
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			return CmdGroupAdd(o, globals, &opts)
+		},
+	}
+}
+
+func CmdGroupUsersUsage(cmd *kingpin.CmdClause, opts *GroupUsersOptions, action string) error {
+	cmd.Arg("GROUP", "Name of the group").Required().StringVar(&opts.Group)
+	cmd.Arg("USER", fmt.Sprintf("Account id, username or email address of the user to %s the group", action)).Required().StringsVar(&opts.Users)
+	return nil
+}
+
+// CmdGroupAdd adds the users to the group.
+func CmdGroupAdd(o *oreo.Client, globals *jiracli.GlobalOptions, opts *GroupUsersOptions) error {
+	for _, account := range opts.Users {
+		user, err := findUser(o, globals, account)
+		if err != nil {
+			return err
+		}
+		if err := jira.GroupAddUser(o, globals.Endpoint.Value, opts.Group, user); err != nil {
+			return err
+		}
+		if !globals.Quiet.Value {
+			fmt.Printf("OK %s added to %s\n", user.DisplayName, opts.Group)
+		}
+	}
+	return nil
+}
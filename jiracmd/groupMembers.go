@@ -0,0 +1,51 @@
+package jiracmd
+
+import (
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type GroupMembersOptions struct {
+	jiracli.CommonOptions `yaml:",inline" json:",inline" figtree:",inline"`
+	Group                 string `yaml:"group,omitempty" json:"group,omitempty"`
+	IncludeInactive       bool   `yaml:"include-inactive,omitempty" json:"include-inactive,omitempty"`
+}
+
+func CmdGroupMembersRegistry() *jiracli.CommandRegistryEntry {
+	opts := GroupMembersOptions{
+		CommonOptions: jiracli.CommonOptions{
+			Template: figtree.NewStringOption("users"),
+		},
+	}
+
+	return &jiracli.CommandRegistryEntry{
+		"Prints the members of a group",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdGroupMembersUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			return CmdGroupMembers(o, globals, &opts)
+		},
+	}
+}
+
+func CmdGroupMembersUsage(cmd *kingpin.CmdClause, opts *GroupMembersOptions) error {
+	jiracli.TemplateUsage(cmd, &opts.CommonOptions)
+	jiracli.GJsonQueryUsage(cmd, &opts.CommonOptions)
+	cmd.Flag("inactive", "Include inactive users").BoolVar(&opts.IncludeInactive)
+	cmd.Arg("GROUP", "Name of the group").Required().StringVar(&opts.Group)
+	return nil
+}
+
+// CmdGroupMembers prints all the members of the group.
+func CmdGroupMembers(o *oreo.Client, globals *jiracli.GlobalOptions, opts *GroupMembersOptions) error {
+	users, err := jira.GroupMembers(o, globals.Endpoint.Value, opts.Group, opts.IncludeInactive)
+	if err != nil {
+		return err
+	}
+	return opts.PrintTemplate(users)
+}
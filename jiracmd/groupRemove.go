@@ -0,0 +1,43 @@
+package jiracmd
+
+import (
+	"fmt"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+func CmdGroupRemoveRegistry() *jiracli.CommandRegistryEntry {
+	opts := GroupUsersOptions{}
+
+	return &jiracli.CommandRegistryEntry{
+		"Remove users from a group",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdGroupUsersUsage(cmd, &opts, "remove from")
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			return CmdGroupRemove(o, globals, &opts)
+		},
+	}
+}
+
+// CmdGroupRemove removes the users from the group.
+func CmdGroupRemove(o *oreo.Client, globals *jiracli.GlobalOptions, opts *GroupUsersOptions) error {
+	for _, account := range opts.Users {
+		user, err := findUser(o, globals, account)
+		if err != nil {
+			return err
+		}
+		if err := jira.GroupRemoveUser(o, globals.Endpoint.Value, opts.Group, user); err != nil {
+			return err
+		}
+		if !globals.Quiet.Value {
+			fmt.Printf("OK %s removed from %s\n", user.DisplayName, opts.Group)
+		}
+	}
+	return nil
+}
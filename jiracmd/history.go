@@ -0,0 +1,211 @@
+package jiracmd
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	"github.com/go-jira/jira/jiradata"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type HistoryOptions struct {
+	jiracli.CommonOptions `yaml:",inline" json:",inline" figtree:",inline"`
+	Project               string   `yaml:"project,omitempty" json:"project,omitempty"`
+	Issue                 string   `yaml:"issue,omitempty" json:"issue,omitempty"`
+	Fields                []string `yaml:"fields,omitempty" json:"fields,omitempty"`
+}
+
+func CmdHistoryRegistry() *jiracli.CommandRegistryEntry {
+	opts := HistoryOptions{
+		CommonOptions: jiracli.CommonOptions{
+			Template: figtree.NewStringOption("history"),
+		},
+	}
+	return &jiracli.CommandRegistryEntry{
+		"Prints the change history of an issue",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdHistoryUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			opts.Issue = jiracli.FormatIssue(opts.Issue, opts.Project)
+			return CmdHistory(o, globals, &opts)
+		},
+	}
+}
+
+func CmdHistoryUsage(cmd *kingpin.CmdClause, opts *HistoryOptions) error {
+	jiracli.BrowseUsage(cmd, &opts.CommonOptions)
+	jiracli.TemplateUsage(cmd, &opts.CommonOptions)
+	jiracli.GJsonQueryUsage(cmd, &opts.CommonOptions)
+	cmd.Flag("field", "Only show changes to this field name or id, can be repeated").StringsVar(&opts.Fields)
+	cmd.Arg("ISSUE", "issue id to fetch history").Required().StringVar(&opts.Issue)
+	return nil
+}
+
+type historyItem struct {
+	*jiradata.ChangeItem
+	// Diff is a word level diff of FromString and ToString for long text
+	// fields, using the "git diff --word-diff" markers [-removed-] and
+	// {+added+}.
+	Diff string `json:"diff,omitempty" yaml:"diff,omitempty"`
+}
+
+type historyEntry struct {
+	ID      string         `json:"id,omitempty" yaml:"id,omitempty"`
+	Author  *jiradata.User `json:"author,omitempty" yaml:"author,omitempty"`
+	Created string         `json:"created,omitempty" yaml:"created,omitempty"`
+	Items   []*historyItem `json:"items,omitempty" yaml:"items,omitempty"`
+}
+
+// CmdHistory will fetch the changelog of the issue and send the changes to
+// the "history" template.
+func CmdHistory(o *oreo.Client, globals *jiracli.GlobalOptions, opts *HistoryOptions) error {
+	issue, histories, err := getIssueHistories(o, globals.Endpoint.Value, opts.Issue, []string{"summary"})
+	if err != nil {
+		return err
+	}
+
+	fields := map[string]bool{}
+	for _, field := range opts.Fields {
+		for _, name := range strings.Split(field, ",") {
+			fields[strings.ToLower(strings.TrimSpace(name))] = true
+		}
+	}
+
+	entries := []*historyEntry{}
+	for _, history := range histories {
+		entry := &historyEntry{
+			ID:      history.ID,
+			Author:  history.Author,
+			Created: history.Created,
+		}
+		for _, item := range history.Items {
+			if len(fields) > 0 && !fields[strings.ToLower(item.Field)] && !fields[strings.ToLower(item.FieldID)] {
+				continue
+			}
+			hi := &historyItem{ChangeItem: item}
+			if item.Field == "description" || item.Field == "environment" || strings.Contains(item.FromString+item.ToString, "\n") {
+				hi.Diff = wordDiff(item.FromString, item.ToString)
+			}
+			entry.Items = append(entry.Items, hi)
+		}
+		if len(entry.Items) > 0 {
+			entries = append(entries, entry)
+		}
+	}
+
+	summary, _ := issue.Fields["summary"].(string)
+	if err := opts.PrintTemplate(struct {
+		Issue     string          `json:"issue" yaml:"issue"`
+		Summary   string          `json:"summary" yaml:"summary"`
+		Histories []*historyEntry `json:"histories" yaml:"histories"`
+	}{issue.Key, summary, entries}); err != nil {
+		return err
+	}
+	if opts.Browse.Value {
+		return CmdBrowse(globals, opts.Issue)
+	}
+	return nil
+}
+
+// getIssueHistories fetches the issue with the changelog expanded.  The
+// expanded changelog may be truncated for issues with long histories, in which
+// case the remaining histories are fetched from the changelog resource.
+func getIssueHistories(o *oreo.Client, endpoint, key string, fields []string) (*jiradata.Issue, jiradata.Histories, error) {
+	issue, err := jira.GetIssue(o, endpoint, key, &jira.IssueOptions{
+		Fields: fields,
+		Expand: []string{"changelog"},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	histories := jiradata.Histories{}
+	if issue.Changelog != nil {
+		histories = issue.Changelog.Histories
+		if issue.Changelog.Total > len(histories) {
+			all, err := jira.GetIssueChangelog(o, endpoint, key)
+			if err != nil {
+				return nil, nil, err
+			}
+			histories = *all
+		}
+	}
+	return issue, histories, nil
+}
+
+var wordDiffTokens = regexp.MustCompile(`\s+|[^\s]+`)
+
+// maxWordDiff limits the size of the table used to compute the longest common
+// subsequence, beyond this the whole text is reported as replaced.
+const maxWordDiff = 4000000
+
+// wordDiff returns the difference between from and to, marking removed words
+// with [-...-] and added words with {+...+}.
+func wordDiff(from, to string) string {
+	a := wordDiffTokens.FindAllString(from, -1)
+	b := wordDiffTokens.FindAllString(to, -1)
+
+	buf := bytes.NewBufferString("")
+	removed, added := []string{}, []string{}
+	flush := func() {
+		if len(removed) > 0 {
+			buf.WriteString("[-" + strings.Join(removed, "") + "-]")
+			removed = removed[:0]
+		}
+		if len(added) > 0 {
+			buf.WriteString("{+" + strings.Join(added, "") + "+}")
+			added = added[:0]
+		}
+	}
+
+	if (len(a)+1)*(len(b)+1) > maxWordDiff {
+		removed, added = a, b
+		flush()
+		return buf.String()
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:]
+	// and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			flush()
+			buf.WriteString(a[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			removed = append(removed, a[i])
+			i++
+		default:
+			added = append(added, b[j])
+			j++
+		}
+	}
+	removed = append(removed, a[i:]...)
+	added = append(added, b[j:]...)
+	flush()
+	return buf.String()
+}
@@ -0,0 +1,23 @@
+package jiracmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordDiff(t *testing.T) {
+	for _, test := range []struct {
+		from, to, expected string
+	}{
+		{"same text", "same text", "same text"},
+		{"", "new text", "{+new text+}"},
+		{"old text", "", "[-old text-]"},
+		{"the quick fox", "the slow fox", "the [-quick-]{+slow+} fox"},
+		{"one two three", "one three", "one [-two -]three"},
+		{"one three", "one two three", "one {+two +}three"},
+		{"a b\nc", "a b\nd", "a b\n[-c-]{+d+}"},
+	} {
+		assert.Equal(t, test.expected, wordDiff(test.from, test.to), "%q -> %q", test.from, test.to)
+	}
+}
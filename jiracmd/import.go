@@ -0,0 +1,589 @@
+package jiracmd
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	"github.com/go-jira/jira/jiradata"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+	yaml "gopkg.in/coryb/yaml.v2"
+)
+
+type ImportOptions struct {
+	jiracli.CommonOptions `yaml:",inline" json:",inline" figtree:",inline"`
+	Project               string            `yaml:"project,omitempty" json:"project,omitempty"`
+	IssueType             string            `yaml:"issuetype,omitempty" json:"issuetype,omitempty"`
+	ImportFile            string            `yaml:"import-file,omitempty" json:"import-file,omitempty"`
+	Format                string            `yaml:"format,omitempty" json:"format,omitempty"`
+	Columns               map[string]string `yaml:"columns,omitempty" json:"columns,omitempty"`
+	StateFile             string            `yaml:"state-file,omitempty" json:"state-file,omitempty"`
+	DryRun                bool              `yaml:"dryrun,omitempty" json:"dryrun,omitempty"`
+}
+
+func CmdImportRegistry() *jiracli.CommandRegistryEntry {
+	opts := ImportOptions{
+		CommonOptions: jiracli.CommonOptions{
+			Template: figtree.NewStringOption("import"),
+		},
+		IssueType: "Task",
+		Columns:   map[string]string{},
+	}
+
+	return &jiracli.CommandRegistryEntry{
+		"Create issues from a YAML or CSV file",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdImportUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			if opts.StateFile == "" && opts.ImportFile != "-" {
+				opts.StateFile = opts.ImportFile + ".state"
+			}
+			return CmdImport(o, globals, &opts)
+		},
+	}
+}
+
+func CmdImportUsage(cmd *kingpin.CmdClause, opts *ImportOptions) error {
+	jiracli.TemplateUsage(cmd, &opts.CommonOptions)
+	cmd.Flag("project", "Project of the issues that do not set one").Short('p').StringVar(&opts.Project)
+	cmd.Flag("issuetype", "Issue type of the issues that do not set one, defaults to Task").StringVar(&opts.IssueType)
+	cmd.Flag("format", "Format of FILE: yaml or csv, detected from the file extension by default").EnumVar(&opts.Format, "yaml", "csv")
+	cmd.Flag("column", "Map a CSV column to a field, like --column Title=summary").StringMapVar(&opts.Columns)
+	cmd.Flag("state", "File recording the created issues to resume a failed import, defaults to FILE.state").StringVar(&opts.StateFile)
+	cmd.Flag("dryrun", "Only check the rows against the create screens").BoolVar(&opts.DryRun)
+	cmd.Arg("FILE", "file to import, or - for stdin").Required().StringVar(&opts.ImportFile)
+	return nil
+}
+
+// importRow is an issue to create.  Rows can refer to each other by ref for
+// their parent and links.
+type importRow struct {
+	Row       int    `json:"row" yaml:"row"`
+	Ref       string `json:"ref" yaml:"ref"`
+	Summary   string `json:"summary" yaml:"summary"`
+	Key       string `json:"key" yaml:"key"`
+	Status    string `json:"status" yaml:"status"`
+	Error     string `json:"error" yaml:"error"`
+	project   string
+	issueType string
+	parent    string
+	links     []*applyLink
+	values    map[string]interface{}
+	depth     int
+	contentID string
+}
+
+const (
+	importCreated = "created"
+	importExists  = "exists"
+	importValid   = "valid"
+	importFailed  = "failed"
+)
+
+// id identifies the row in the state file.  Rows without a ref are
+// identified by their content rather than their position, so rows added or
+// removed before resuming a failed import do not shift the other rows.
+func (r *importRow) id() string {
+	if r.Ref != "" {
+		return r.Ref
+	}
+	return r.contentID
+}
+
+// setContentIDs identifies the rows by a hash of their project, issue type
+// and summary, numbering rows with the same content in file order.
+func setContentIDs(rows []*importRow) {
+	seen := map[string]int{}
+	for _, row := range rows {
+		sum := sha1.Sum([]byte(strings.Join([]string{row.project, strings.ToLower(row.issueType), row.Summary}, "\x00")))
+		id := fmt.Sprintf("row-%x", sum[:8])
+		seen[id]++
+		if seen[id] > 1 {
+			id = fmt.Sprintf("%s-%d", id, seen[id])
+		}
+		row.contentID = id
+	}
+}
+
+func (r *importRow) fail(format string, args ...interface{}) {
+	r.Status = importFailed
+	if r.Error != "" {
+		r.Error += "; "
+	}
+	r.Error += fmt.Sprintf(format, args...)
+}
+
+// importState records the issues and links already created so a failed
+// import can be run again without creating duplicates.
+type importState struct {
+	Issues map[string]string `yaml:"issues,omitempty"`
+	Links  []string          `yaml:"links,omitempty"`
+}
+
+// CmdImport will create the issues of the file, parents before their children
+// and subtasks, then create the links between them.  Field names are resolved
+// with the create screen of each issue type.  Every row is reported with the
+// "import" template, and failed rows are created when the import is run again.
+func CmdImport(o *oreo.Client, globals *jiracli.GlobalOptions, opts *ImportOptions) error {
+	var data []byte
+	var err error
+	if opts.ImportFile == "-" {
+		data, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		data, err = ioutil.ReadFile(opts.ImportFile)
+	}
+	if err != nil {
+		return err
+	}
+
+	format := opts.Format
+	if format == "" {
+		format = "yaml"
+		if strings.EqualFold(filepath.Ext(opts.ImportFile), ".csv") {
+			format = "csv"
+		}
+	}
+	var rows []*importRow
+	if format == "csv" {
+		rows, err = parseImportCSV(data, opts.Columns)
+	} else {
+		rows, err = parseImportYAML(data)
+	}
+	if err != nil {
+		return err
+	}
+
+	state := &importState{Issues: map[string]string{}}
+	if opts.StateFile != "" {
+		if data, err := ioutil.ReadFile(opts.StateFile); err == nil {
+			if err := yaml.Unmarshal(data, state); err != nil {
+				return fmt.Errorf("Invalid state file %s: %s", opts.StateFile, err)
+			}
+			if state.Issues == nil {
+				state.Issues = map[string]string{}
+			}
+		}
+	}
+	saveState := func() error {
+		if opts.StateFile == "" || opts.DryRun {
+			return nil
+		}
+		data, err := yaml.Marshal(state)
+		if err != nil {
+			return err
+		}
+		return ioutil.WriteFile(opts.StateFile, data, 0644)
+	}
+
+	refs := map[string]*importRow{}
+	for _, row := range rows {
+		if row.project == "" {
+			row.project = opts.Project
+		}
+		if row.issueType == "" {
+			row.issueType = opts.IssueType
+		}
+		if row.Ref != "" {
+			if _, ok := refs[row.Ref]; ok {
+				return fmt.Errorf("ref %q is used by more than one row", row.Ref)
+			}
+			refs[row.Ref] = row
+		}
+	}
+	setContentIDs(rows)
+	for _, row := range rows {
+		if err := importDepth(row, refs, map[string]bool{}); err != nil {
+			return err
+		}
+	}
+	ordered := append([]*importRow{}, rows...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].depth < ordered[j].depth
+	})
+
+	if globals.JiraDeploymentType.Value == "" {
+		serverInfo, err := jira.ServerInfo(o, globals.Endpoint.Value)
+		if err != nil {
+			return err
+		}
+		globals.JiraDeploymentType.Value = strings.ToLower(serverInfo.DeploymentType)
+	}
+	cloud := globals.JiraDeploymentType.Value == jiracli.CloudDeploymentType
+
+	resolve := func(ref string, project string) (string, bool) {
+		if target, ok := refs[ref]; ok {
+			return target.Key, target.Key != ""
+		}
+		return jiracli.FormatIssue(ref, project), true
+	}
+
+	meta := map[string]*jiradata.IssueType{}
+	for _, row := range ordered {
+		if key, ok := state.Issues[row.id()]; ok {
+			row.Key = key
+			row.Status = importExists
+			continue
+		}
+		if row.project == "" {
+			row.fail("project is required")
+			continue
+		}
+		metaKey := row.project + "\x00" + row.issueType
+		if _, ok := meta[metaKey]; !ok {
+			issueType, err := jira.GetIssueCreateMetaIssueType(o, globals.Endpoint.Value, row.project, row.issueType)
+			if err != nil {
+				row.fail("%s", err)
+				continue
+			}
+			meta[metaKey] = issueType
+		}
+		issueType := meta[metaKey]
+
+		fields := map[string]interface{}{
+			"project":   map[string]interface{}{"key": row.project},
+			"issuetype": map[string]interface{}{"name": issueType.Name},
+		}
+		for name, value := range row.values {
+			id := importFieldID(issueType.Fields, name)
+			if id == "" {
+				row.fail("%q is not on the create screen of %s %s", name, row.project, issueType.Name)
+				continue
+			}
+			converted, err := importFieldValue(issueType.Fields[id], value, cloud)
+			if err != nil {
+				row.fail("%s: %s", name, err)
+				continue
+			}
+			fields[id] = converted
+		}
+		if row.parent != "" {
+			parentKey, ok := resolve(row.parent, row.project)
+			if !ok && !opts.DryRun {
+				row.fail("parent %s was not created", row.parent)
+			} else if id := importParentField(issueType); id == "" {
+				row.fail("%s issues cannot have a parent", issueType.Name)
+			} else if id == "parent" {
+				fields[id] = map[string]interface{}{"key": parentKey}
+			} else {
+				fields[id] = parentKey
+			}
+		}
+		if row.Status == importFailed {
+			continue
+		}
+		if cloud {
+			if err := fixGDPRUserFields(o, globals.Endpoint.Value, issueType.Fields, fields); err != nil {
+				row.fail("%s", err)
+				continue
+			}
+		}
+		if opts.DryRun {
+			row.Status = importValid
+			continue
+		}
+
+		resp, err := jira.CreateIssue(o, globals.Endpoint.Value, &jiradata.IssueUpdate{Fields: fields})
+		if err != nil {
+			row.fail("%s", err)
+			continue
+		}
+		row.Key = resp.Key
+		row.Status = importCreated
+		state.Issues[row.id()] = resp.Key
+		if err := saveState(); err != nil {
+			return err
+		}
+	}
+
+	linked := map[string]bool{}
+	for _, link := range state.Links {
+		linked[link] = true
+	}
+	for _, row := range ordered {
+		if row.Key == "" || opts.DryRun {
+			continue
+		}
+		for _, link := range row.links {
+			other, inward := link.Outward, false
+			if other == "" {
+				other, inward = link.Inward, true
+			}
+			id := strings.Join([]string{row.id(), link.Type, link.Outward, link.Inward}, "|")
+			if linked[id] {
+				continue
+			}
+			otherKey, ok := resolve(other, row.project)
+			if !ok {
+				row.fail("not linked to %s as it was not created", other)
+				continue
+			}
+			// the inward issue of the request is the one doing the outward action
+			request := &jiradata.LinkIssueRequest{
+				Type:         &jiradata.IssueLinkType{Name: link.Type},
+				InwardIssue:  &jiradata.IssueRef{Key: row.Key},
+				OutwardIssue: &jiradata.IssueRef{Key: otherKey},
+			}
+			if inward {
+				request.InwardIssue, request.OutwardIssue = request.OutwardIssue, request.InwardIssue
+			}
+			if err := jira.LinkIssues(o, globals.Endpoint.Value, request); err != nil {
+				row.fail("link %s %s: %s", link.Type, other, err)
+				continue
+			}
+			state.Links = append(state.Links, id)
+			linked[id] = true
+			if err := saveState(); err != nil {
+				return err
+			}
+		}
+	}
+
+	if !globals.Quiet.Value {
+		if err := opts.PrintTemplate(struct {
+			Issues []*importRow `json:"issues" yaml:"issues"`
+		}{rows}); err != nil {
+			return err
+		}
+	}
+	failed := 0
+	for _, row := range rows {
+		if row.Status == importFailed {
+			failed++
+		}
+	}
+	if failed > 0 {
+		if opts.StateFile != "" && !opts.DryRun {
+			return fmt.Errorf("%d of %d rows failed, run the import again to retry them, created issues are recorded in %s", failed, len(rows), opts.StateFile)
+		}
+		return fmt.Errorf("%d of %d rows failed", failed, len(rows))
+	}
+	return nil
+}
+
+// importDepth sets the number of parents of the row within the file, so
+// parents can be created first.
+func importDepth(row *importRow, refs map[string]*importRow, visiting map[string]bool) error {
+	if row.depth > 0 || row.parent == "" {
+		return nil
+	}
+	parent, ok := refs[row.parent]
+	if !ok {
+		return nil
+	}
+	if visiting[row.id()] {
+		return fmt.Errorf("ref %q is its own parent", row.Ref)
+	}
+	visiting[row.id()] = true
+	if err := importDepth(parent, refs, visiting); err != nil {
+		return err
+	}
+	row.depth = parent.depth + 1
+	return nil
+}
+
+// importSpecial handles the keys of a row that are not issue fields.
+func importSpecial(row *importRow, name string, value interface{}) bool {
+	switch normalizeColumn(name) {
+	case "ref":
+		row.Ref = fmt.Sprint(value)
+	case "project":
+		row.project = fmt.Sprint(value)
+	case "issuetype", "type":
+		row.issueType = fmt.Sprint(value)
+	case "parent", "epic":
+		row.parent = fmt.Sprint(value)
+	case "links":
+		if list, ok := value.([]interface{}); ok {
+			jiracli.ConvertType(list, &row.links)
+		} else {
+			row.links = parseImportLinks(fmt.Sprint(value))
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+var importLinkPattern = regexp.MustCompile(`^\s*(.+?)\s*:\s*(\S+)\s*$`)
+
+// parseImportLinks parses the links of a CSV row, written as "Blocks:ref" for
+// an outward link and separated with semicolons.
+func parseImportLinks(value string) []*applyLink {
+	links := []*applyLink{}
+	for _, link := range strings.Split(value, ";") {
+		if match := importLinkPattern.FindStringSubmatch(link); match != nil {
+			links = append(links, &applyLink{Type: match[1], Outward: match[2]})
+		}
+	}
+	return links
+}
+
+// parseImportYAML returns a row for each document of the YAML data.  Keys of
+// the document are fields, except for ref, project, issuetype, parent and
+// links, and the fields under a fields key.
+func parseImportYAML(data []byte) ([]*importRow, error) {
+	defer func(mapType, iface reflect.Type) {
+		yaml.DefaultMapType = mapType
+		yaml.IfaceType = iface
+	}(yaml.DefaultMapType, yaml.IfaceType)
+	yaml.DefaultMapType = reflect.TypeOf(map[string]interface{}{})
+	yaml.IfaceType = yaml.DefaultMapType.Elem()
+
+	rows := []*importRow{}
+	docs := regexp.MustCompile(`(?m)^---[ \t]*$`).Split(string(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))), -1)
+	for _, doc := range docs {
+		if strings.TrimSpace(doc) == "" {
+			continue
+		}
+		values := map[string]interface{}{}
+		if err := yaml.Unmarshal([]byte(doc), &values); err != nil {
+			return nil, fmt.Errorf("Invalid YAML in document %d: %s", len(rows)+1, err)
+		}
+		row := &importRow{Row: len(rows) + 1, values: map[string]interface{}{}}
+		for name, value := range values {
+			if name == "fields" {
+				if fields, ok := value.(map[string]interface{}); ok {
+					for name, value := range fields {
+						row.values[name] = value
+					}
+					continue
+				}
+			}
+			if !importSpecial(row, name, value) {
+				row.values[name] = value
+			}
+		}
+		row.Summary = fmt.Sprint(importValue(row.values, "summary"))
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// parseImportCSV returns a row for each line of the CSV data.  Columns are
+// mapped to fields by their name unless they are in columns, a column mapped
+// to "-" being ignored.
+func parseImportCSV(data []byte, columns map[string]string) ([]*importRow, error) {
+	records, err := readImportCSV(data)
+	if err != nil {
+		return nil, err
+	}
+	mapping := map[string]string{}
+	for column, field := range columns {
+		mapping[normalizeColumn(column)] = field
+	}
+	rows := []*importRow{}
+	for i, record := range records {
+		row := &importRow{Row: i + 2, values: map[string]interface{}{}}
+		for column, value := range record {
+			name := column
+			if field, ok := mapping[column]; ok {
+				name = field
+			}
+			if name == "-" || value == "" {
+				continue
+			}
+			if !importSpecial(row, name, value) {
+				row.values[name] = value
+			}
+		}
+		row.Summary = fmt.Sprint(importValue(row.values, "summary"))
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func importValue(values map[string]interface{}, name string) interface{} {
+	for key, value := range values {
+		if normalizeColumn(key) == name {
+			return value
+		}
+	}
+	return ""
+}
+
+// importFieldID returns the id of the field of the create screen with the
+// given id or name.
+func importFieldID(meta jiradata.FieldMetaMap, name string) string {
+	if _, ok := meta[name]; ok {
+		return name
+	}
+	for id, field := range meta {
+		if normalizeColumn(field.Name) == normalizeColumn(name) || normalizeColumn(id) == normalizeColumn(name) {
+			return id
+		}
+	}
+	return ""
+}
+
+// importParentField returns the field used to set the parent of issues of the
+// issue type: the parent of subtasks, the epic link of classic projects or
+// the parent of other issues in team managed projects.
+func importParentField(issueType *jiradata.IssueType) string {
+	if issueType.Subtask {
+		return "parent"
+	}
+	for id, field := range issueType.Fields {
+		if field.Schema != nil && strings.HasSuffix(field.Schema.Custom, ":gh-epic-link") {
+			return id
+		}
+	}
+	if _, ok := issueType.Fields["parent"]; ok {
+		return "parent"
+	}
+	return ""
+}
+
+// importFieldValue converts text values to the structure expected by the
+// field, like {name: High} for a priority.  Lists are written separated by
+// commas.
+func importFieldValue(meta *jiradata.FieldMeta, value interface{}, cloud bool) (interface{}, error) {
+	text, ok := value.(string)
+	if !ok || meta.Schema == nil {
+		return value, nil
+	}
+	switch meta.Schema.Type {
+	case "number":
+		return strconv.ParseFloat(strings.TrimSpace(text), 64)
+	case "array":
+		items := []interface{}{}
+		for _, item := range strings.Split(text, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, importItemValue(meta.Schema.Items, item, cloud))
+			}
+		}
+		return items, nil
+	}
+	return importItemValue(meta.Schema.Type, text, cloud), nil
+}
+
+func importItemValue(kind, text string, cloud bool) interface{} {
+	switch kind {
+	case "priority", "version", "component", "resolution", "securitylevel":
+		return map[string]interface{}{"name": text}
+	case "option":
+		return map[string]interface{}{"value": text}
+	case "issuelink":
+		return map[string]interface{}{"key": text}
+	case "user":
+		if cloud {
+			// resolved to an accountId by fixGDPRUserFields
+			return map[string]interface{}{"displayName": text}
+		}
+		return map[string]interface{}{"name": text}
+	}
+	return text
+}
@@ -0,0 +1,109 @@
+package jiracmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseImportYAML(t *testing.T) {
+	rows, err := parseImportYAML([]byte(`ref: epic
+issuetype: Epic
+summary: The epic
+labels: [a, b]
+---
+parent: epic
+project: ABC
+summary: A story
+links:
+  - type: Blocks
+    outward: other
+fields:
+  customfield_10: 5
+---
+`))
+	assert.NoError(t, err)
+	assert.Len(t, rows, 2)
+
+	assert.Equal(t, 1, rows[0].Row)
+	assert.Equal(t, "epic", rows[0].Ref)
+	assert.Equal(t, "Epic", rows[0].issueType)
+	assert.Equal(t, "The epic", rows[0].Summary)
+	assert.Equal(t, map[string]interface{}{
+		"summary": "The epic",
+		"labels":  []interface{}{"a", "b"},
+	}, rows[0].values)
+
+	assert.Equal(t, 2, rows[1].Row)
+	assert.Equal(t, "epic", rows[1].parent)
+	assert.Equal(t, "ABC", rows[1].project)
+	assert.Equal(t, []*applyLink{{Type: "Blocks", Outward: "other"}}, rows[1].links)
+	assert.Equal(t, map[string]interface{}{
+		"summary":        "A story",
+		"customfield_10": 5,
+	}, rows[1].values)
+
+	_, err = parseImportYAML([]byte("summary: [unclosed\n"))
+	assert.Error(t, err)
+}
+
+func TestParseImportCSV(t *testing.T) {
+	rows, err := parseImportCSV([]byte("\xef\xbb\xbfRef,Title,Issue Type,Parent,Links,Notes,Story Points\n"+
+		"a,First,Story,,Blocks:b; Relates:ABC-1,ignored,3\n"+
+		"b,Second,Sub-task,a,,,\n"), map[string]string{
+		"Title": "summary",
+		"Notes": "-",
+	})
+	assert.NoError(t, err)
+	assert.Len(t, rows, 2)
+
+	assert.Equal(t, 2, rows[0].Row)
+	assert.Equal(t, "a", rows[0].Ref)
+	assert.Equal(t, "Story", rows[0].issueType)
+	assert.Equal(t, "First", rows[0].Summary)
+	assert.Equal(t, []*applyLink{{Type: "Blocks", Outward: "b"}, {Type: "Relates", Outward: "ABC-1"}}, rows[0].links)
+	assert.Equal(t, map[string]interface{}{"summary": "First", "storypoints": "3"}, rows[0].values)
+
+	assert.Equal(t, 3, rows[1].Row)
+	assert.Equal(t, "a", rows[1].parent)
+	assert.Equal(t, "Sub-task", rows[1].issueType)
+	assert.Equal(t, map[string]interface{}{"summary": "Second"}, rows[1].values)
+}
+
+func TestImportDepth(t *testing.T) {
+	epic := &importRow{Ref: "epic"}
+	story := &importRow{Ref: "story", parent: "epic"}
+	subtask := &importRow{Ref: "subtask", parent: "story"}
+	outside := &importRow{Ref: "outside", parent: "ABC-1"}
+	refs := map[string]*importRow{"epic": epic, "story": story, "subtask": subtask, "outside": outside}
+	for _, row := range []*importRow{subtask, story, epic, outside} {
+		assert.NoError(t, importDepth(row, refs, map[string]bool{}))
+	}
+	assert.Equal(t, 0, epic.depth)
+	assert.Equal(t, 1, story.depth)
+	assert.Equal(t, 2, subtask.depth)
+	assert.Equal(t, 0, outside.depth)
+
+	a := &importRow{Ref: "a", parent: "b"}
+	b := &importRow{Ref: "b", parent: "a"}
+	assert.EqualError(t, importDepth(a, map[string]*importRow{"a": a, "b": b}, map[string]bool{}), `ref "a" is its own parent`)
+}
+
+func TestSetContentIDs(t *testing.T) {
+	row := func(summary string) *importRow {
+		return &importRow{project: "ABC", issueType: "Task", Summary: summary}
+	}
+	rows := []*importRow{row("one"), row("two"), row("one"), {Ref: "ref", Summary: "one"}}
+	setContentIDs(rows)
+	assert.Regexp(t, `^row-[0-9a-f]{16}$`, rows[0].id())
+	assert.NotEqual(t, rows[0].id(), rows[1].id())
+	assert.Equal(t, rows[0].id()+"-2", rows[2].id())
+	assert.Equal(t, "ref", rows[3].id())
+
+	// inserting a row does not change the ids of the other rows
+	edited := []*importRow{row("new"), row("one"), row("two"), row("one")}
+	setContentIDs(edited)
+	assert.Equal(t, rows[0].id(), edited[1].id())
+	assert.Equal(t, rows[1].id(), edited[2].id())
+	assert.Equal(t, rows[2].id(), edited[3].id())
+}
@@ -0,0 +1,135 @@
+package jiracmd
+
+import (
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type LinkListOptions struct {
+	jiracli.CommonOptions `yaml:",inline" json:",inline" figtree:",inline"`
+	Project               string `yaml:"project,omitempty" json:"project,omitempty"`
+	Issue                 string `yaml:"issue,omitempty" json:"issue,omitempty"`
+}
+
+func CmdLinkListRegistry() *jiracli.CommandRegistryEntry {
+	opts := LinkListOptions{
+		CommonOptions: jiracli.CommonOptions{
+			Template: figtree.NewStringOption("link-list"),
+		},
+	}
+
+	return &jiracli.CommandRegistryEntry{
+		"Prints the issue links of an issue grouped by link type",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdLinkListUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			opts.Issue = jiracli.FormatIssue(opts.Issue, opts.Project)
+			return CmdLinkList(o, globals, &opts)
+		},
+	}
+}
+
+func CmdLinkListUsage(cmd *kingpin.CmdClause, opts *LinkListOptions) error {
+	jiracli.BrowseUsage(cmd, &opts.CommonOptions)
+	jiracli.TemplateUsage(cmd, &opts.CommonOptions)
+	jiracli.GJsonQueryUsage(cmd, &opts.CommonOptions)
+	cmd.Arg("ISSUE", "Issue id to lookup links").Required().StringVar(&opts.Issue)
+	return nil
+}
+
+type linkedIssue struct {
+	ID        string `json:"id" yaml:"id"`
+	Key       string `json:"key" yaml:"key"`
+	Summary   string `json:"summary" yaml:"summary"`
+	Status    string `json:"status" yaml:"status"`
+	IssueType string `json:"issuetype" yaml:"issuetype"`
+}
+
+// linkGroup holds the links of one link type in one direction, Label is the
+// description of the direction, ie "blocks" or "is blocked by".
+type linkGroup struct {
+	Type      string         `json:"type" yaml:"type"`
+	Direction string         `json:"direction" yaml:"direction"`
+	Label     string         `json:"label" yaml:"label"`
+	Issues    []*linkedIssue `json:"issues" yaml:"issues"`
+}
+
+type linkList struct {
+	Issue  string       `json:"issue" yaml:"issue"`
+	Groups []*linkGroup `json:"groups" yaml:"groups"`
+}
+
+// CmdLinkList will group the "issuelinks" field of the issue by link type
+// and direction and send it to the "link-list" template.
+func CmdLinkList(o *oreo.Client, globals *jiracli.GlobalOptions, opts *LinkListOptions) error {
+	links, err := getIssueLinks(o, globals.Endpoint.Value, opts.Issue)
+	if err != nil {
+		return err
+	}
+
+	data := groupIssueLinks(opts.Issue, links)
+	if err := opts.PrintTemplate(data); err != nil {
+		return err
+	}
+	if opts.Browse.Value {
+		return CmdBrowse(globals, opts.Issue)
+	}
+	return nil
+}
+
+// groupIssueLinks groups the links by link type and direction, in the order
+// the link types are first found.
+func groupIssueLinks(issue string, links []*issueLink) *linkList {
+	data := &linkList{Issue: issue}
+	groups := map[string]*linkGroup{}
+	for _, link := range links {
+		if link.Type == nil {
+			continue
+		}
+		group := &linkGroup{Type: link.Type.Name}
+		other := link.OutwardIssue
+		if other != nil {
+			group.Direction, group.Label = "outward", link.Type.Outward
+		} else if link.InwardIssue != nil {
+			other = link.InwardIssue
+			group.Direction, group.Label = "inward", link.Type.Inward
+		} else {
+			continue
+		}
+		id := group.Type + "\x00" + group.Direction
+		if existing, ok := groups[id]; ok {
+			group = existing
+		} else {
+			groups[id] = group
+			data.Groups = append(data.Groups, group)
+		}
+		node := newGraphNode(other)
+		group.Issues = append(group.Issues, &linkedIssue{
+			ID:        link.ID,
+			Key:       node.Key,
+			Summary:   node.Summary,
+			Status:    node.Status,
+			IssueType: node.IssueType,
+		})
+	}
+	return data
+}
+
+func getIssueLinks(o *oreo.Client, endpoint, issue string) ([]*issueLink, error) {
+	data, err := jira.GetIssue(o, endpoint, issue, &jira.IssueOptions{
+		Fields: []string{"issuelinks"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	links := []*issueLink{}
+	if err := jiracli.ConvertType(data.Fields["issuelinks"], &links); err != nil {
+		return nil, err
+	}
+	return links, nil
+}
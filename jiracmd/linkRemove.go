@@ -0,0 +1,104 @@
+package jiracmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type LinkRemoveOptions struct {
+	Project  string `yaml:"project,omitempty" json:"project,omitempty"`
+	Issue    string `yaml:"issue,omitempty" json:"issue,omitempty"`
+	Other    string `yaml:"other,omitempty" json:"other,omitempty"`
+	LinkType string `yaml:"linktype,omitempty" json:"linktype,omitempty"`
+}
+
+func CmdLinkRemoveRegistry() *jiracli.CommandRegistryEntry {
+	opts := LinkRemoveOptions{}
+
+	return &jiracli.CommandRegistryEntry{
+		"Remove the link between two issues",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdLinkRemoveUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			opts.Issue = jiracli.FormatIssue(opts.Issue, opts.Project)
+			opts.Other = jiracli.FormatIssue(opts.Other, opts.Project)
+			return CmdLinkRemove(o, globals, &opts)
+		},
+	}
+}
+
+func CmdLinkRemoveUsage(cmd *kingpin.CmdClause, opts *LinkRemoveOptions) error {
+	cmd.Flag("type", "Link type name or description (ie: blocks, is blocked by) of the link to remove").StringVar(&opts.LinkType)
+	cmd.Arg("ISSUE", "Issue id to remove the link from").Required().StringVar(&opts.Issue)
+	cmd.Arg("OTHER", "Linked issue id").Required().StringVar(&opts.Other)
+	return nil
+}
+
+// CmdLinkRemove will find the links between the two issues in the
+// "issuelinks" field of ISSUE and delete them.  Without --type there must be
+// exactly one link between the issues.
+func CmdLinkRemove(o *oreo.Client, globals *jiracli.GlobalOptions, opts *LinkRemoveOptions) error {
+	links, err := getIssueLinks(o, globals.Endpoint.Value, opts.Issue)
+	if err != nil {
+		return err
+	}
+
+	matches, err := matchIssueLinks(links, opts.Issue, opts.Other, opts.LinkType)
+	if err != nil {
+		return err
+	}
+
+	for _, link := range matches {
+		if err := jira.DeleteIssueLink(o, globals.Endpoint.Value, link.ID); err != nil {
+			return err
+		}
+	}
+
+	if !globals.Quiet.Value {
+		fmt.Printf("OK %s %s\n", opts.Issue, jira.URLJoin(globals.Endpoint.Value, "browse", opts.Issue))
+		fmt.Printf("OK %s %s\n", opts.Other, jira.URLJoin(globals.Endpoint.Value, "browse", opts.Other))
+	}
+	return nil
+}
+
+// matchIssueLinks returns the links of issue to other matching the link type
+// name or direction description.  Without a link type there must be exactly
+// one link between the issues.
+func matchIssueLinks(links []*issueLink, issue, other, linkType string) ([]*issueLink, error) {
+	matches := []*issueLink{}
+	found := []string{}
+	for _, link := range links {
+		if link.Type == nil {
+			continue
+		}
+		linked, label := link.OutwardIssue, link.Type.Outward
+		if linked == nil {
+			linked, label = link.InwardIssue, link.Type.Inward
+		}
+		if linked == nil || !strings.EqualFold(linked.Key, other) {
+			continue
+		}
+		found = append(found, fmt.Sprintf("%q", label))
+		if linkType == "" || strings.EqualFold(linkType, link.Type.Name) || strings.EqualFold(linkType, label) {
+			matches = append(matches, link)
+		}
+	}
+
+	switch {
+	case len(found) == 0:
+		return nil, fmt.Errorf("%s is not linked to %s", issue, other)
+	case len(matches) == 0:
+		return nil, fmt.Errorf("%s has no %q link to %s, found %s", issue, linkType, other, strings.Join(found, ", "))
+	case len(matches) > 1 && linkType == "":
+		return nil, fmt.Errorf("%s has %d links to %s (%s), use --type to choose one", issue, len(matches), other, strings.Join(found, ", "))
+	}
+	return matches, nil
+}
@@ -0,0 +1,81 @@
+package jiracmd
+
+import (
+	"testing"
+
+	"github.com/go-jira/jira/jiradata"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	blocksLinkType  = &jiradata.IssueLinkType{Name: "Blocks", Inward: "is blocked by", Outward: "blocks"}
+	relatesLinkType = &jiradata.IssueLinkType{Name: "Relates", Inward: "relates to", Outward: "relates to"}
+)
+
+func testLinkedIssue(key, summary string) *jiradata.Issue {
+	return &jiradata.Issue{
+		Key: key,
+		Fields: map[string]interface{}{
+			"summary":   summary,
+			"status":    map[string]interface{}{"name": "To Do"},
+			"issuetype": map[string]interface{}{"name": "Task"},
+		},
+	}
+}
+
+func testIssueLinks() []*issueLink {
+	return []*issueLink{
+		{ID: "1", Type: blocksLinkType, OutwardIssue: testLinkedIssue("A-2", "two")},
+		{ID: "2", Type: relatesLinkType, OutwardIssue: testLinkedIssue("A-3", "three")},
+		{ID: "3", Type: blocksLinkType, InwardIssue: testLinkedIssue("A-4", "four")},
+		{ID: "4", Type: blocksLinkType, OutwardIssue: testLinkedIssue("A-5", "five")},
+		{ID: "5", Type: relatesLinkType, InwardIssue: testLinkedIssue("A-2", "two")},
+		{ID: "6", Type: nil, OutwardIssue: testLinkedIssue("A-6", "six")},
+	}
+}
+
+func TestGroupIssueLinks(t *testing.T) {
+	issue := func(id, key, summary string) *linkedIssue {
+		return &linkedIssue{ID: id, Key: key, Summary: summary, Status: "To Do", IssueType: "Task"}
+	}
+	assert.Equal(t, &linkList{
+		Issue: "A-1",
+		Groups: []*linkGroup{
+			{Type: "Blocks", Direction: "outward", Label: "blocks", Issues: []*linkedIssue{issue("1", "A-2", "two"), issue("4", "A-5", "five")}},
+			{Type: "Relates", Direction: "outward", Label: "relates to", Issues: []*linkedIssue{issue("2", "A-3", "three")}},
+			{Type: "Blocks", Direction: "inward", Label: "is blocked by", Issues: []*linkedIssue{issue("3", "A-4", "four")}},
+			{Type: "Relates", Direction: "inward", Label: "relates to", Issues: []*linkedIssue{issue("5", "A-2", "two")}},
+		},
+	}, groupIssueLinks("A-1", testIssueLinks()))
+	assert.Equal(t, &linkList{Issue: "A-1"}, groupIssueLinks("A-1", nil))
+}
+
+func TestMatchIssueLinks(t *testing.T) {
+	for _, test := range []struct {
+		other, linkType string
+		ids             []string
+		err             string
+	}{
+		{"A-3", "", []string{"2"}, ""},
+		{"a-4", "", []string{"3"}, ""},
+		{"A-4", "is blocked by", []string{"3"}, ""},
+		{"A-2", "blocks", []string{"1"}, ""},
+		{"A-2", "Relates", []string{"5"}, ""},
+		{"A-2", "", nil, `A-1 has 2 links to A-2 ("blocks", "relates to"), use --type to choose one`},
+		{"A-3", "blocks", nil, `A-1 has no "blocks" link to A-3, found "relates to"`},
+		{"A-6", "", nil, "A-1 is not linked to A-6"},
+		{"B-1", "", nil, "A-1 is not linked to B-1"},
+	} {
+		matches, err := matchIssueLinks(testIssueLinks(), "A-1", test.other, test.linkType)
+		if test.err != "" {
+			assert.EqualError(t, err, test.err)
+			continue
+		}
+		assert.NoError(t, err)
+		ids := []string{}
+		for _, match := range matches {
+			ids = append(ids, match.ID)
+		}
+		assert.Equal(t, test.ids, ids, "%s %s", test.other, test.linkType)
+	}
+}
@@ -0,0 +1,314 @@
+package jiracmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	"github.com/go-jira/jira/jiradata"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type MoveOptions struct {
+	jiracli.CommonOptions `yaml:",inline" json:",inline" figtree:",inline"`
+	Project               string   `yaml:"project,omitempty" json:"project,omitempty"`
+	Issues                []string `yaml:"issues,omitempty" json:"issues,omitempty"`
+	TargetProject         string   `yaml:"target-project,omitempty" json:"target-project,omitempty"`
+	IssueType             string   `yaml:"issuetype,omitempty" json:"issuetype,omitempty"`
+	LinkType              string   `yaml:"linktype,omitempty" json:"linktype,omitempty"`
+	CloseTransition       string   `yaml:"close-transition,omitempty" json:"close-transition,omitempty"`
+	DryRun                bool     `yaml:"dryrun,omitempty" json:"dryrun,omitempty"`
+}
+
+func CmdMoveRegistry() *jiracli.CommandRegistryEntry {
+	opts := MoveOptions{
+		CommonOptions: jiracli.CommonOptions{
+			Template: figtree.NewStringOption("move"),
+		},
+		LinkType: "Cloners",
+	}
+
+	return &jiracli.CommandRegistryEntry{
+		"Move issues to another project or issue type",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdMoveUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			if opts.TargetProject == "" && opts.IssueType == "" {
+				return fmt.Errorf("--project or --issuetype is required")
+			}
+			for i, issue := range opts.Issues {
+				opts.Issues[i] = jiracli.FormatIssue(issue, opts.Project)
+			}
+			return CmdMove(o, globals, &opts)
+		},
+	}
+}
+
+func CmdMoveUsage(cmd *kingpin.CmdClause, opts *MoveOptions) error {
+	jiracli.TemplateUsage(cmd, &opts.CommonOptions)
+	jiracli.GJsonQueryUsage(cmd, &opts.CommonOptions)
+	cmd.Flag("project", "Project to move the issues to").StringVar(&opts.TargetProject)
+	cmd.Flag("issuetype", "Issue type to change the issues to").StringVar(&opts.IssueType)
+	cmd.Flag("link-type", "Link type used to link an issue to its copy when it has to be copied").StringVar(&opts.LinkType)
+	cmd.Flag("close-transition", "Transition used to close an issue after copying it, defaults to the first transition to a done status").StringVar(&opts.CloseTransition)
+	cmd.Flag("dryrun", "Only report how the issues would be moved and the fields that would be lost").BoolVar(&opts.DryRun)
+	cmd.Arg("ISSUE", "issues to move").Required().StringsVar(&opts.Issues)
+	return nil
+}
+
+// movedIssue reports how an issue was moved.  Method is "edit" when the
+// issue type was changed in place, or "clone" when the issue was copied,
+// linked to the copy and closed.  Copies and Closed record what was done, so
+// a move that failed part way can be finished or cleaned up by hand.
+type movedIssue struct {
+	From      string         `json:"from" yaml:"from"`
+	To        string         `json:"to" yaml:"to"`
+	URL       string         `json:"url" yaml:"url"`
+	Project   string         `json:"project" yaml:"project"`
+	IssueType string         `json:"issuetype" yaml:"issuetype"`
+	Method    string         `json:"method" yaml:"method"`
+	Subtasks  []string       `json:"subtasks" yaml:"subtasks"`
+	Lost      []string       `json:"lost" yaml:"lost"`
+	Copies    []*clonedIssue `json:"copies" yaml:"copies"`
+	Closed    []string       `json:"closed" yaml:"closed"`
+	Error     string         `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+type moveResult struct {
+	DryRun bool          `json:"dryrun" yaml:"dryrun"`
+	Issues []*movedIssue `json:"issues" yaml:"issues"`
+}
+
+// CmdMove will change the issue type of the issues in place when the issue
+// stays in its project and the edit screen allows it, the subtasks stay with
+// the issue.  The REST API cannot move issues between projects, so otherwise
+// the issue and its subtasks are copied to the new project, linked to their
+// copies and closed.  The fields with values missing from the create screen
+// of the destination are reported as lost.  Nothing is rolled back when a
+// move fails, the copies made and issues closed so far are reported instead.
+// Subtasks given along with a parent that is copied are moved with it.
+func CmdMove(o *oreo.Client, globals *jiracli.GlobalOptions, opts *MoveOptions) error {
+	cloner := newIssueCloner(o, globals.Endpoint.Value, &CloneOptions{
+		WithSubtasks:    true,
+		WithLinks:       true,
+		WithAttachments: true,
+	})
+	result := &moveResult{DryRun: opts.DryRun}
+	issues := []*jiradata.Issue{}
+	for _, key := range opts.Issues {
+		issue, err := jira.GetIssue(o, globals.Endpoint.Value, key, nil)
+		if err != nil {
+			return err
+		}
+		editMeta, err := jira.GetIssueEditMeta(o, globals.Endpoint.Value, key)
+		if err != nil {
+			return err
+		}
+		moved := &movedIssue{
+			From:      issue.Key,
+			Project:   opts.TargetProject,
+			IssueType: opts.IssueType,
+			Method:    "clone",
+		}
+		if moved.Project == "" {
+			moved.Project = issueProjectKey(issue)
+		}
+		if moved.IssueType == "" {
+			moved.IssueType = issueTypeName(issue)
+		}
+		if moved.Project == issueProjectKey(issue) {
+			if name := editableIssueType(editMeta.Fields, moved.IssueType); name != "" {
+				moved.IssueType = name
+				moved.Method = "edit"
+			}
+		}
+
+		createMeta, err := cloner.createMeta(moved.Project, moved.IssueType)
+		if err != nil {
+			return err
+		}
+		moved.Lost = lostFields(issue, editMeta.Fields, createMeta.Fields)
+		subtasks := jiradata.Issues{}
+		if err := jiracli.ConvertType(issue.Fields["subtasks"], &subtasks); err != nil {
+			return err
+		}
+		for _, subtask := range subtasks {
+			moved.Subtasks = append(moved.Subtasks, subtask.Key)
+			if moved.Method != "clone" {
+				continue
+			}
+			// the subtasks are copied with their own issue type, check it
+			// exists in the destination before copying anything
+			subtaskType := issueTypeName(subtask)
+			if _, err := cloner.createMeta(moved.Project, subtaskType); err != nil {
+				return fmt.Errorf("Unable to move subtask %s of %s to %s as %q: %s", subtask.Key, issue.Key, moved.Project, subtaskType, err)
+			}
+		}
+		result.Issues = append(result.Issues, moved)
+		issues = append(issues, issue)
+	}
+
+	issues, result.Issues = skipCopiedSubtasks(issues, result.Issues)
+
+	for i, moved := range result.Issues {
+		if opts.DryRun {
+			continue
+		}
+		if err := moveIssue(o, globals.Endpoint.Value, opts, cloner, issues[i], moved); err != nil {
+			moved.Error = err.Error()
+			// only report the issues moved so far and the failed one
+			result.Issues = result.Issues[:i+1]
+			if !globals.Quiet.Value {
+				opts.PrintTemplate(result)
+			}
+			return fmt.Errorf("Failed to move %s: %s", moved.From, err)
+		}
+	}
+
+	if globals.Quiet.Value {
+		return nil
+	}
+	return opts.PrintTemplate(result)
+}
+
+// skipCopiedSubtasks drops the subtasks whose parent is also being moved by
+// copying it, since the subtasks are copied along with their parent.
+func skipCopiedSubtasks(issues []*jiradata.Issue, moves []*movedIssue) ([]*jiradata.Issue, []*movedIssue) {
+	copied := map[string]bool{}
+	for _, moved := range moves {
+		if moved.Method == "clone" {
+			copied[moved.From] = true
+		}
+	}
+	keepIssues, keepMoves := []*jiradata.Issue{}, []*movedIssue{}
+	for i, issue := range issues {
+		if parent := issueParentKey(issue); parent != "" && copied[parent] {
+			log.Noticef("Skipping %s, it is moved with its parent %s", issue.Key, parent)
+			continue
+		}
+		keepIssues = append(keepIssues, issue)
+		keepMoves = append(keepMoves, moves[i])
+	}
+	return keepIssues, keepMoves
+}
+
+// moveIssue changes the issue type of the issue or copies, links and closes
+// it, recording the copies and closed issues in moved as it goes.
+func moveIssue(o *oreo.Client, endpoint string, opts *MoveOptions, cloner *issueCloner, issue *jiradata.Issue, moved *movedIssue) error {
+	if moved.Method == "edit" {
+		err := jira.EditIssue(o, endpoint, issue.Key, &jiradata.IssueUpdate{
+			Fields: map[string]interface{}{
+				"issuetype": map[string]interface{}{"name": moved.IssueType},
+			},
+		})
+		if err != nil {
+			return err
+		}
+		moved.To = issue.Key
+		moved.URL = jira.URLJoin(endpoint, "browse", moved.To)
+		return nil
+	}
+
+	copied := len(cloner.result.Issues)
+	to, err := cloner.cloneTree(issue, moved.Project, moved.IssueType, issueParentKey(issue))
+	moved.Copies = cloner.result.Issues[copied:]
+	if err != nil {
+		return err
+	}
+	moved.To = to
+	moved.URL = jira.URLJoin(endpoint, "browse", moved.To)
+	// subtasks have to be closed before their parent
+	for _, original := range append(moved.Subtasks, issue.Key) {
+		if err := linkAndClose(o, endpoint, opts.LinkType, opts.CloseTransition, original, cloner.keys[original]); err != nil {
+			return err
+		}
+		moved.Closed = append(moved.Closed, original)
+	}
+	return nil
+}
+
+// editableIssueType returns the name of the issue type if the edit screen
+// allows changing the issue type to it, or an empty string.
+func editableIssueType(editMeta jiradata.FieldMetaMap, issueType string) string {
+	meta, ok := editMeta["issuetype"]
+	if !ok {
+		return ""
+	}
+	for _, value := range meta.AllowedValues {
+		allowed := &jiradata.IssueType{}
+		if err := jiracli.ConvertType(value, allowed); err == nil && strings.EqualFold(allowed.Name, issueType) {
+			return allowed.Name
+		}
+	}
+	return ""
+}
+
+// lostFields returns the names of the editable fields with a value that are
+// not on the create screen of the destination.
+func lostFields(issue *jiradata.Issue, editMeta, createMeta jiradata.FieldMetaMap) []string {
+	lost := []string{}
+	for name, meta := range editMeta {
+		if _, ok := createMeta[name]; ok || skipCloneField(name, meta) {
+			continue
+		}
+		switch name {
+		case "comment", "worklog":
+			// comments and worklogs always stay with the original issue
+			continue
+		}
+		if cloneFieldValue(meta, issue.Fields[name]) == nil {
+			continue
+		}
+		if meta.Name != "" && meta.Name != name {
+			lost = append(lost, fmt.Sprintf("%s (%s)", meta.Name, name))
+		} else {
+			lost = append(lost, name)
+		}
+	}
+	sort.Strings(lost)
+	return lost
+}
+
+// linkAndClose links the original issue to its copy and closes it with the
+// close transition, or the first transition to a done status when empty.
+func linkAndClose(o *oreo.Client, endpoint, linkType, closeTransition, original, copied string) error {
+	if linkType != "" {
+		err := jira.LinkIssues(o, endpoint, &jiradata.LinkIssueRequest{
+			Type:         &jiradata.IssueLinkType{Name: linkType},
+			InwardIssue:  &jiradata.IssueRef{Key: copied},
+			OutwardIssue: &jiradata.IssueRef{Key: original},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	meta, err := jira.GetIssueTransitions(o, endpoint, original)
+	if err != nil {
+		return err
+	}
+	var transition *jiradata.Transition
+	if closeTransition != "" {
+		transition = meta.Transitions.Find(closeTransition)
+	} else {
+		for _, t := range meta.Transitions {
+			if t.To != nil && t.To.StatusCategory != nil && t.To.StatusCategory.Key == "done" {
+				transition = t
+				break
+			}
+		}
+	}
+	if transition == nil {
+		log.Warning("Unable to find a transition to close %s, it was copied to %s", original, copied)
+		return nil
+	}
+	return jira.TransitionIssue(o, endpoint, original, &jiradata.IssueUpdate{
+		Transition: &jiradata.Transition{ID: transition.ID},
+	})
+}
@@ -0,0 +1,74 @@
+package jiracmd
+
+import (
+	"testing"
+
+	"github.com/go-jira/jira/jiradata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEditableIssueType(t *testing.T) {
+	editMeta := jiradata.FieldMetaMap{
+		"issuetype": &jiradata.FieldMeta{
+			AllowedValues: []interface{}{
+				map[string]interface{}{"id": "1", "name": "Bug"},
+				map[string]interface{}{"id": "3", "name": "Task"},
+			},
+		},
+	}
+	assert.Equal(t, "Task", editableIssueType(editMeta, "Task"))
+	assert.Equal(t, "Bug", editableIssueType(editMeta, "bug"))
+	assert.Equal(t, "", editableIssueType(editMeta, "Story"))
+	assert.Equal(t, "", editableIssueType(jiradata.FieldMetaMap{}, "Task"))
+}
+
+func TestLostFields(t *testing.T) {
+	editMeta := jiradata.FieldMetaMap{
+		"summary":       &jiradata.FieldMeta{Name: "Summary"},
+		"labels":        &jiradata.FieldMeta{Name: "Labels"},
+		"environment":   &jiradata.FieldMeta{Name: "Environment"},
+		"comment":       &jiradata.FieldMeta{Name: "Comment"},
+		"issuelinks":    &jiradata.FieldMeta{Name: "Linked Issues"},
+		"customfield_1": &jiradata.FieldMeta{Name: "Team"},
+		"customfield_2": &jiradata.FieldMeta{Name: "Sprint", Schema: &jiradata.JSONType{Custom: "com.pyxis.greenhopper.jira:gh-sprint"}},
+		"customfield_3": &jiradata.FieldMeta{},
+	}
+	createMeta := jiradata.FieldMetaMap{
+		"summary": &jiradata.FieldMeta{Name: "Summary"},
+	}
+	issue := &jiradata.Issue{
+		Fields: map[string]interface{}{
+			"summary":       "Moved",
+			"labels":        []interface{}{"a"},
+			"environment":   "",
+			"comment":       map[string]interface{}{"comments": []interface{}{"x"}},
+			"issuelinks":    []interface{}{map[string]interface{}{"id": "1"}},
+			"customfield_1": "Core",
+			"customfield_2": []interface{}{"sprint"},
+			"customfield_3": 5.0,
+		},
+	}
+	assert.Equal(t, []string{"Labels (labels)", "Team (customfield_1)", "customfield_3"}, lostFields(issue, editMeta, createMeta))
+	assert.Equal(t, []string{}, lostFields(issue, editMeta, editMeta))
+}
+
+func TestSkipCopiedSubtasks(t *testing.T) {
+	parent := &jiradata.Issue{Key: "A-1", Fields: map[string]interface{}{}}
+	subtask := &jiradata.Issue{Key: "A-2", Fields: map[string]interface{}{"parent": map[string]interface{}{"key": "A-1"}}}
+	other := &jiradata.Issue{Key: "B-2", Fields: map[string]interface{}{"parent": map[string]interface{}{"key": "B-1"}}}
+
+	issues, moves := skipCopiedSubtasks(
+		[]*jiradata.Issue{subtask, parent, other},
+		[]*movedIssue{{From: "A-2", Method: "clone"}, {From: "A-1", Method: "clone"}, {From: "B-2", Method: "clone"}},
+	)
+	assert.Equal(t, []*jiradata.Issue{parent, other}, issues)
+	assert.Equal(t, []string{"A-1", "B-2"}, []string{moves[0].From, moves[1].From})
+
+	// subtasks stay with a parent edited in place, so they can be moved too
+	issues, moves = skipCopiedSubtasks(
+		[]*jiradata.Issue{parent, subtask},
+		[]*movedIssue{{From: "A-1", Method: "edit"}, {From: "A-2", Method: "edit"}},
+	)
+	assert.Equal(t, []*jiradata.Issue{parent, subtask}, issues)
+	assert.Len(t, moves, 2)
+}
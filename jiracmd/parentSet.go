@@ -0,0 +1,37 @@
+package jiracmd
+
+import (
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira/jiracli"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+func CmdParentSetRegistry() *jiracli.CommandRegistryEntry {
+	opts := ReparentOptions{
+		CommonOptions: jiracli.CommonOptions{
+			Template: figtree.NewStringOption("reparent"),
+		},
+		LinkType: "Cloners",
+	}
+
+	return &jiracli.CommandRegistryEntry{
+		"Move a subtask to another parent issue",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdParentSetUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			opts.Issue = jiracli.FormatIssue(opts.Issue, opts.Project)
+			opts.Parent = jiracli.FormatIssue(opts.Parent, opts.Project)
+			return CmdReparent(o, globals, &opts)
+		},
+	}
+}
+
+func CmdParentSetUsage(cmd *kingpin.CmdClause, opts *ReparentOptions) error {
+	reparentUsage(cmd, opts)
+	cmd.Arg("SUBTASK", "issue to move").Required().StringVar(&opts.Issue)
+	cmd.Arg("PARENT", "new parent issue").Required().StringVar(&opts.Parent)
+	return nil
+}
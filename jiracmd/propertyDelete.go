@@ -0,0 +1,50 @@
+package jiracmd
+
+import (
+	"fmt"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type PropertyDeleteOptions struct {
+	Project string `yaml:"project,omitempty" json:"project,omitempty"`
+	Issue   string `yaml:"issue,omitempty" json:"issue,omitempty"`
+	Key     string `yaml:"key,omitempty" json:"key,omitempty"`
+}
+
+func CmdPropertyDeleteRegistry() *jiracli.CommandRegistryEntry {
+	opts := PropertyDeleteOptions{}
+
+	return &jiracli.CommandRegistryEntry{
+		"Delete an issue property",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdPropertyDeleteUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			opts.Issue = jiracli.FormatIssue(opts.Issue, opts.Project)
+			return CmdPropertyDelete(o, globals, &opts)
+		},
+	}
+}
+
+func CmdPropertyDeleteUsage(cmd *kingpin.CmdClause, opts *PropertyDeleteOptions) error {
+	cmd.Arg("ISSUE", "Issue id to delete the property from").Required().StringVar(&opts.Issue)
+	cmd.Arg("KEY", "Property key").Required().StringVar(&opts.Key)
+	return nil
+}
+
+func CmdPropertyDelete(o *oreo.Client, globals *jiracli.GlobalOptions, opts *PropertyDeleteOptions) error {
+	if err := jira.DeleteIssueProperty(o, globals.Endpoint.Value, opts.Issue, opts.Key); err != nil {
+		return err
+	}
+
+	if !globals.Quiet.Value {
+		fmt.Printf("OK %s %s\n", opts.Issue, jira.URLJoin(globals.Endpoint.Value, "browse", opts.Issue))
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package jiracmd
+
+import (
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type PropertyGetOptions struct {
+	jiracli.CommonOptions `yaml:",inline" json:",inline" figtree:",inline"`
+	Project               string `yaml:"project,omitempty" json:"project,omitempty"`
+	Issue                 string `yaml:"issue,omitempty" json:"issue,omitempty"`
+	Key                   string `yaml:"key,omitempty" json:"key,omitempty"`
+}
+
+func CmdPropertyGetRegistry() *jiracli.CommandRegistryEntry {
+	opts := PropertyGetOptions{
+		CommonOptions: jiracli.CommonOptions{
+			Template: figtree.NewStringOption("property"),
+		},
+	}
+
+	return &jiracli.CommandRegistryEntry{
+		"Prints the JSON value of an issue property",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdPropertyGetUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			opts.Issue = jiracli.FormatIssue(opts.Issue, opts.Project)
+			return CmdPropertyGet(o, globals, &opts)
+		},
+	}
+}
+
+func CmdPropertyGetUsage(cmd *kingpin.CmdClause, opts *PropertyGetOptions) error {
+	jiracli.TemplateUsage(cmd, &opts.CommonOptions)
+	jiracli.GJsonQueryUsage(cmd, &opts.CommonOptions)
+	cmd.Arg("ISSUE", "Issue id to lookup the property").Required().StringVar(&opts.Issue)
+	cmd.Arg("KEY", "Property key").Required().StringVar(&opts.Key)
+	return nil
+}
+
+func CmdPropertyGet(o *oreo.Client, globals *jiracli.GlobalOptions, opts *PropertyGetOptions) error {
+	data, err := jira.GetIssueProperty(o, globals.Endpoint.Value, opts.Issue, opts.Key)
+	if err != nil {
+		return err
+	}
+	return opts.PrintTemplate(data)
+}
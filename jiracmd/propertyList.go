@@ -0,0 +1,50 @@
+package jiracmd
+
+import (
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type PropertyListOptions struct {
+	jiracli.CommonOptions `yaml:",inline" json:",inline" figtree:",inline"`
+	Project               string `yaml:"project,omitempty" json:"project,omitempty"`
+	Issue                 string `yaml:"issue,omitempty" json:"issue,omitempty"`
+}
+
+func CmdPropertyListRegistry() *jiracli.CommandRegistryEntry {
+	opts := PropertyListOptions{
+		CommonOptions: jiracli.CommonOptions{
+			Template: figtree.NewStringOption("properties"),
+		},
+	}
+
+	return &jiracli.CommandRegistryEntry{
+		"Prints the property keys of an issue",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdPropertyListUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			opts.Issue = jiracli.FormatIssue(opts.Issue, opts.Project)
+			return CmdPropertyList(o, globals, &opts)
+		},
+	}
+}
+
+func CmdPropertyListUsage(cmd *kingpin.CmdClause, opts *PropertyListOptions) error {
+	jiracli.TemplateUsage(cmd, &opts.CommonOptions)
+	jiracli.GJsonQueryUsage(cmd, &opts.CommonOptions)
+	cmd.Arg("ISSUE", "Issue id to lookup properties").Required().StringVar(&opts.Issue)
+	return nil
+}
+
+func CmdPropertyList(o *oreo.Client, globals *jiracli.GlobalOptions, opts *PropertyListOptions) error {
+	data, err := jira.GetIssuePropertyKeys(o, globals.Endpoint.Value, opts.Issue)
+	if err != nil {
+		return err
+	}
+	return opts.PrintTemplate(data)
+}
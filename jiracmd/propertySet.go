@@ -0,0 +1,77 @@
+package jiracmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type PropertySetOptions struct {
+	Project   string `yaml:"project,omitempty" json:"project,omitempty"`
+	Issue     string `yaml:"issue,omitempty" json:"issue,omitempty"`
+	Key       string `yaml:"key,omitempty" json:"key,omitempty"`
+	Value     string `yaml:"value,omitempty" json:"value,omitempty"`
+	ValueFile string `yaml:"value-file,omitempty" json:"value-file,omitempty"`
+}
+
+func CmdPropertySetRegistry() *jiracli.CommandRegistryEntry {
+	opts := PropertySetOptions{}
+
+	return &jiracli.CommandRegistryEntry{
+		"Set an issue property to a JSON value",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdPropertySetUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			opts.Issue = jiracli.FormatIssue(opts.Issue, opts.Project)
+			return CmdPropertySet(o, globals, &opts)
+		},
+	}
+}
+
+func CmdPropertySetUsage(cmd *kingpin.CmdClause, opts *PropertySetOptions) error {
+	cmd.Flag("value-file", "Read the JSON value from file, use - for stdin").StringVar(&opts.ValueFile)
+	cmd.Arg("ISSUE", "Issue id to set the property on").Required().StringVar(&opts.Issue)
+	cmd.Arg("KEY", "Property key").Required().StringVar(&opts.Key)
+	cmd.Arg("JSON-VALUE", "JSON value of the property, read from stdin when not given").StringVar(&opts.Value)
+	return nil
+}
+
+// CmdPropertySet will read the JSON value from the argument, the value file
+// or stdin and store it in the issue property.
+func CmdPropertySet(o *oreo.Client, globals *jiracli.GlobalOptions, opts *PropertySetOptions) error {
+	raw := []byte(opts.Value)
+	var err error
+	switch {
+	case opts.Value != "" && opts.ValueFile != "":
+		return fmt.Errorf("JSON-VALUE and --value-file cannot be used together")
+	case opts.ValueFile == "-" || (opts.Value == "" && opts.ValueFile == ""):
+		raw, err = ioutil.ReadAll(os.Stdin)
+	case opts.ValueFile != "":
+		raw, err = ioutil.ReadFile(opts.ValueFile)
+	}
+	if err != nil {
+		return err
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return fmt.Errorf("Invalid JSON value for property %s: %s", opts.Key, err)
+	}
+	if err := jira.SetIssueProperty(o, globals.Endpoint.Value, opts.Issue, opts.Key, value); err != nil {
+		return err
+	}
+
+	if !globals.Quiet.Value {
+		fmt.Printf("OK %s %s\n", opts.Issue, jira.URLJoin(globals.Endpoint.Value, "browse", opts.Issue))
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import "github.com/go-jira/jira/jiracli"
 
 func RegisterAllCommands() {
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "acknowledge", Entry: CmdTransitionRegistry("acknowledge"), Aliases: []string{"ack"}})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "apply", Entry: CmdApplyRegistry()})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "assign", Entry: CmdAssignRegistry(), Aliases: []string{"give"}})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "attach create", Entry: CmdAttachCreateRegistry()})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "attach get", Entry: CmdAttachGetRegistry()})
@@ -12,12 +13,18 @@ func RegisterAllCommands() {
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "backlog", Entry: CmdTransitionRegistry("Backlog")})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "block", Entry: CmdBlockRegistry()})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "browse", Entry: CmdBrowseRegistry(), Aliases: []string{"b"}})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "clone", Entry: CmdCloneRegistry()})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "close", Entry: CmdTransitionRegistry("close")})
-	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "comment", Entry: CmdCommentRegistry()})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "comment add", Entry: CmdCommentRegistry(), Default: true, Operation: "comment"})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "comment delete", Entry: CmdCommentDeleteRegistry(), Aliases: []string{"rm"}})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "comment edit", Entry: CmdCommentEditRegistry()})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "comment list", Entry: CmdCommentListRegistry(), Aliases: []string{"ls"}})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "component add", Entry: CmdComponentAddRegistry()})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "components", Entry: CmdComponentsRegistry()})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "create", Entry: CmdCreateRegistry()})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "createmeta", Entry: CmdCreateMetaRegistry()})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "cycle-time", Entry: CmdCycleTimeRegistry()})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "delete", Entry: CmdDeleteRegistry()})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "done", Entry: CmdTransitionRegistry("Done")})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "dup", Entry: CmdDupRegistry()})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "edit", Entry: CmdEditRegistry()})
@@ -26,8 +33,15 @@ func RegisterAllCommands() {
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "epic create", Entry: CmdEpicCreateRegistry()})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "epic list", Entry: CmdEpicListRegistry(), Aliases: []string{"ls"}})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "epic remove", Entry: CmdEpicRemoveRegistry(), Aliases: []string{"rm"}})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "export", Entry: CmdExportRegistry()})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "export-templates", Entry: CmdExportTemplatesRegistry()})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "fields", Entry: CmdFieldsRegistry()})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "graph", Entry: CmdGraphRegistry()})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "group add", Entry: CmdGroupAddRegistry()})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "group members", Entry: CmdGroupMembersRegistry()})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "group remove", Entry: CmdGroupRemoveRegistry(), Aliases: []string{"rm"}})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "history", Entry: CmdHistoryRegistry()})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "import", Entry: CmdImportRegistry()})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "in-progress", Entry: CmdTransitionRegistry("Progress"), Aliases: []string{"prog", "progress"}})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "issuelink", Entry: CmdIssueLinkRegistry()})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "issuelinktypes", Entry: CmdIssueLinkTypesRegistry()})
@@ -35,27 +49,52 @@ func RegisterAllCommands() {
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "labels add", Entry: CmdLabelsAddRegistry()})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "labels remove", Entry: CmdLabelsRemoveRegistry(), Aliases: []string{"rm"}})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "labels set", Entry: CmdLabelsSetRegistry()})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "link list", Entry: CmdLinkListRegistry(), Aliases: []string{"ls"}})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "link remove", Entry: CmdLinkRemoveRegistry(), Aliases: []string{"rm"}})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "list", Entry: CmdListRegistry(), Aliases: []string{"ls"}})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "login", Entry: CmdLoginRegistry()})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "logout", Entry: CmdLogoutRegistry()})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "move", Entry: CmdMoveRegistry()})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "parent set", Entry: CmdParentSetRegistry()})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "property delete", Entry: CmdPropertyDeleteRegistry(), Aliases: []string{"rm"}})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "property get", Entry: CmdPropertyGetRegistry()})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "property list", Entry: CmdPropertyListRegistry(), Aliases: []string{"ls"}})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "property set", Entry: CmdPropertySetRegistry()})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "rank", Entry: CmdRankRegistry()})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "remotelink add", Entry: CmdRemoteLinkAddRegistry()})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "remotelink list", Entry: CmdRemoteLinkListRegistry(), Aliases: []string{"ls"}})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "remotelink remove", Entry: CmdRemoteLinkRemoveRegistry(), Aliases: []string{"rm"}})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "reopen", Entry: CmdTransitionRegistry("reopen")})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "report burndown", Entry: CmdReportBurndownRegistry()})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "report velocity", Entry: CmdReportVelocityRegistry()})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "request", Entry: CmdRequestRegistry(), Aliases: []string{"req"}})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "resolve", Entry: CmdTransitionRegistry("resolve")})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "start", Entry: CmdTransitionRegistry("start")})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "stop", Entry: CmdTransitionRegistry("stop")})
-	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "subtask", Entry: CmdSubtaskRegistry()})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "subtask convert", Entry: CmdSubtaskConvertRegistry()})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "subtask create", Entry: CmdSubtaskRegistry(), Default: true, Operation: "subtask"})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "subtask promote", Entry: CmdSubtaskPromoteRegistry()})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "take", Entry: CmdTakeRegistry()})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "timer start", Entry: CmdTimerStartRegistry()})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "timer status", Entry: CmdTimerStatusRegistry(), Default: true})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "timer stop", Entry: CmdTimerStopRegistry()})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "timesheet", Entry: CmdTimesheetRegistry()})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "todo", Entry: CmdTransitionRegistry("To Do")})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "transition", Entry: CmdTransitionRegistry(""), Aliases: []string{"trans"}})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "transitions", Entry: CmdTransitionsRegistry("transitions")})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "transmeta", Entry: CmdTransitionsRegistry("debug")})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "tree", Entry: CmdTreeRegistry()})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "unassign", Entry: CmdUnassignRegistry()})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "unexport-templates", Entry: CmdUnexportTemplatesRegistry()})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "user search", Entry: CmdUserSearchRegistry()})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "user show", Entry: CmdUserShowRegistry()})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "view", Entry: CmdViewRegistry()})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "vote", Entry: CmdVoteRegistry()})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "watch", Entry: CmdWatchRegistry()})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "worklog add", Entry: CmdWorklogAddRegistry()})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "worklog delete", Entry: CmdWorklogDeleteRegistry(), Aliases: []string{"rm"}})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "worklog edit", Entry: CmdWorklogEditRegistry()})
+	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "worklog import", Entry: CmdWorklogImportRegistry()})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "worklog list", Entry: CmdWorklogListRegistry(), Default: true})
 	jiracli.RegisterCommand(jiracli.CommandRegistry{Command: "session", Entry: CmdSessionRegistry()})
 }
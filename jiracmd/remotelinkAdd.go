@@ -0,0 +1,87 @@
+package jiracmd
+
+import (
+	"fmt"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	"github.com/go-jira/jira/jiradata"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type RemoteLinkAddOptions struct {
+	jiracli.CommonOptions `yaml:",inline" json:",inline" figtree:",inline"`
+	jiradata.RemoteLink   `yaml:",inline" json:",inline" figtree:",inline"`
+	Project               string `yaml:"project,omitempty" json:"project,omitempty"`
+	Issue                 string `yaml:"issue,omitempty" json:"issue,omitempty"`
+}
+
+func CmdRemoteLinkAddRegistry() *jiracli.CommandRegistryEntry {
+	opts := RemoteLinkAddOptions{
+		RemoteLink: jiradata.RemoteLink{
+			Application: &jiradata.RemoteLinkApplication{},
+			Object: &jiradata.RemoteLinkObject{
+				Status: &jiradata.RemoteLinkStatus{},
+			},
+		},
+	}
+
+	return &jiracli.CommandRegistryEntry{
+		"Add or update a web link on an issue",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdRemoteLinkAddUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			opts.Issue = jiracli.FormatIssue(opts.Issue, opts.Project)
+			if opts.Object.Title == "" {
+				opts.Object.Title = opts.Object.URL
+			}
+			if opts.GlobalID == "" {
+				// the url identifies the link so adding it again updates it
+				opts.GlobalID = opts.Object.URL
+			}
+			if opts.Application.Name == "" && opts.Application.Type == "" {
+				opts.Application = nil
+			}
+			if !opts.Object.Status.Resolved {
+				opts.Object.Status = nil
+			}
+			return CmdRemoteLinkAdd(o, globals, &opts)
+		},
+	}
+}
+
+func CmdRemoteLinkAddUsage(cmd *kingpin.CmdClause, opts *RemoteLinkAddOptions) error {
+	jiracli.BrowseUsage(cmd, &opts.CommonOptions)
+	cmd.Flag("title", "Title of the link, defaults to the URL").StringVar(&opts.Object.Title)
+	cmd.Flag("summary", "Summary shown below the title").StringVar(&opts.Object.Summary)
+	cmd.Flag("global-id", "Id identifying the link, adding a link with the same id updates it, defaults to the URL").StringVar(&opts.GlobalID)
+	cmd.Flag("relationship", "Relationship of the issue to the link, ie: \"mentioned in\"").StringVar(&opts.Relationship)
+	cmd.Flag("application-name", "Name of the application the link belongs to, links are grouped by application").StringVar(&opts.Application.Name)
+	cmd.Flag("application-type", "Type of the application the link belongs to, ie: com.github").StringVar(&opts.Application.Type)
+	cmd.Flag("resolved", "Show the link as resolved (struck out)").BoolVar(&opts.Object.Status.Resolved)
+	cmd.Arg("ISSUE", "issue to add the link to").Required().StringVar(&opts.Issue)
+	cmd.Arg("URL", "url of the link").Required().StringVar(&opts.Object.URL)
+	return nil
+}
+
+// CmdRemoteLinkAdd will add the web link to the issue, or update the link
+// with the same global id.
+func CmdRemoteLinkAdd(o *oreo.Client, globals *jiracli.GlobalOptions, opts *RemoteLinkAddOptions) error {
+	link, err := jira.AddIssueRemoteLink(o, globals.Endpoint.Value, opts.Issue, &opts.RemoteLink)
+	if err != nil {
+		return err
+	}
+
+	if !globals.Quiet.Value {
+		fmt.Printf("OK %s %s remotelink %d\n", opts.Issue, jira.URLJoin(globals.Endpoint.Value, "browse", opts.Issue), link.ID)
+	}
+
+	if opts.Browse.Value {
+		return CmdBrowse(globals, opts.Issue)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package jiracmd
+
+import (
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type RemoteLinkListOptions struct {
+	jiracli.CommonOptions `yaml:",inline" json:",inline" figtree:",inline"`
+	Project               string `yaml:"project,omitempty" json:"project,omitempty"`
+	Issue                 string `yaml:"issue,omitempty" json:"issue,omitempty"`
+}
+
+func CmdRemoteLinkListRegistry() *jiracli.CommandRegistryEntry {
+	opts := RemoteLinkListOptions{
+		CommonOptions: jiracli.CommonOptions{
+			Template: figtree.NewStringOption("remotelinks"),
+		},
+	}
+
+	return &jiracli.CommandRegistryEntry{
+		"Prints the web links of an issue",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdRemoteLinkListUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			opts.Issue = jiracli.FormatIssue(opts.Issue, opts.Project)
+			return CmdRemoteLinkList(o, globals, &opts)
+		},
+	}
+}
+
+func CmdRemoteLinkListUsage(cmd *kingpin.CmdClause, opts *RemoteLinkListOptions) error {
+	jiracli.BrowseUsage(cmd, &opts.CommonOptions)
+	jiracli.TemplateUsage(cmd, &opts.CommonOptions)
+	jiracli.GJsonQueryUsage(cmd, &opts.CommonOptions)
+	cmd.Arg("ISSUE", "Issue id to lookup web links").Required().StringVar(&opts.Issue)
+	return nil
+}
+
+func CmdRemoteLinkList(o *oreo.Client, globals *jiracli.GlobalOptions, opts *RemoteLinkListOptions) error {
+	links, err := jira.GetIssueRemoteLinks(o, globals.Endpoint.Value, opts.Issue)
+	if err != nil {
+		return err
+	}
+	if err := opts.PrintTemplate(links); err != nil {
+		return err
+	}
+	if opts.Browse.Value {
+		return CmdBrowse(globals, opts.Issue)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package jiracmd
+
+import (
+	"fmt"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type RemoteLinkRemoveOptions struct {
+	Project  string `yaml:"project,omitempty" json:"project,omitempty"`
+	Issue    string `yaml:"issue,omitempty" json:"issue,omitempty"`
+	LinkID   string `yaml:"link-id,omitempty" json:"link-id,omitempty"`
+	GlobalID string `yaml:"global-id,omitempty" json:"global-id,omitempty"`
+}
+
+func CmdRemoteLinkRemoveRegistry() *jiracli.CommandRegistryEntry {
+	opts := RemoteLinkRemoveOptions{}
+
+	return &jiracli.CommandRegistryEntry{
+		"Remove a web link from an issue",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdRemoteLinkRemoveUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			opts.Issue = jiracli.FormatIssue(opts.Issue, opts.Project)
+			if opts.LinkID == "" && opts.GlobalID == "" {
+				return fmt.Errorf("LINK-ID or --global-id is required")
+			}
+			return CmdRemoteLinkRemove(o, globals, &opts)
+		},
+	}
+}
+
+func CmdRemoteLinkRemoveUsage(cmd *kingpin.CmdClause, opts *RemoteLinkRemoveOptions) error {
+	cmd.Flag("global-id", "Remove the link with this global id instead of LINK-ID").StringVar(&opts.GlobalID)
+	cmd.Arg("ISSUE", "Issue id to remove the web link from").Required().StringVar(&opts.Issue)
+	cmd.Arg("LINK-ID", "Id of the web link to remove").StringVar(&opts.LinkID)
+	return nil
+}
+
+func CmdRemoteLinkRemove(o *oreo.Client, globals *jiracli.GlobalOptions, opts *RemoteLinkRemoveOptions) error {
+	if opts.GlobalID != "" {
+		if err := jira.DeleteIssueRemoteLinkByGlobalID(o, globals.Endpoint.Value, opts.Issue, opts.GlobalID); err != nil {
+			return err
+		}
+	} else if err := jira.DeleteIssueRemoteLink(o, globals.Endpoint.Value, opts.Issue, opts.LinkID); err != nil {
+		return err
+	}
+
+	if !globals.Quiet.Value {
+		fmt.Printf("OK %s %s\n", opts.Issue, jira.URLJoin(globals.Endpoint.Value, "browse", opts.Issue))
+	}
+	return nil
+}
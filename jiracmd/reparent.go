@@ -0,0 +1,159 @@
+package jiracmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	"github.com/go-jira/jira/jiradata"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+// ReparentOptions are shared by the commands changing the parent of an issue
+// or converting an issue between a subtask and a standard issue.
+type ReparentOptions struct {
+	jiracli.CommonOptions `yaml:",inline" json:",inline" figtree:",inline"`
+	Project               string `yaml:"project,omitempty" json:"project,omitempty"`
+	Issue                 string `yaml:"issue,omitempty" json:"issue,omitempty"`
+	Parent                string `yaml:"parent,omitempty" json:"parent,omitempty"`
+	IssueType             string `yaml:"issuetype,omitempty" json:"issuetype,omitempty"`
+	LinkType              string `yaml:"linktype,omitempty" json:"linktype,omitempty"`
+	CloseTransition       string `yaml:"close-transition,omitempty" json:"close-transition,omitempty"`
+	DryRun                bool   `yaml:"dryrun,omitempty" json:"dryrun,omitempty"`
+}
+
+// reparentResult reports how an issue was changed.  Method is "edit" when the
+// issue was updated in place, or "clone" when the issue was copied, linked to
+// the copy and closed.
+type reparentResult struct {
+	DryRun    bool     `json:"dryrun" yaml:"dryrun"`
+	From      string   `json:"from" yaml:"from"`
+	To        string   `json:"to" yaml:"to"`
+	URL       string   `json:"url" yaml:"url"`
+	Parent    string   `json:"parent" yaml:"parent"`
+	IssueType string   `json:"issuetype" yaml:"issuetype"`
+	Method    string   `json:"method" yaml:"method"`
+	Lost      []string `json:"lost" yaml:"lost"`
+}
+
+// CmdReparent will set the parent and issue type of the issue, an empty
+// parent making it a standard issue.  The issue is updated in place when its
+// edit screen allows changing the parent and issue type.  Otherwise the issue
+// is copied with the new parent and issue type, linked to the copy and
+// closed, the fields with values missing from the create screen of the copy
+// being reported as lost.
+func CmdReparent(o *oreo.Client, globals *jiracli.GlobalOptions, opts *ReparentOptions) error {
+	issue, err := jira.GetIssue(o, globals.Endpoint.Value, opts.Issue, nil)
+	if err != nil {
+		return err
+	}
+	editMeta, err := jira.GetIssueEditMeta(o, globals.Endpoint.Value, opts.Issue)
+	if err != nil {
+		return err
+	}
+	result := &reparentResult{
+		DryRun:    opts.DryRun,
+		From:      issue.Key,
+		Parent:    opts.Parent,
+		IssueType: opts.IssueType,
+		Method:    "edit",
+	}
+	if result.IssueType == "" {
+		result.IssueType = issueTypeName(issue)
+	}
+	if result.Parent == issue.Key {
+		return fmt.Errorf("%s cannot be its own parent", issue.Key)
+	}
+
+	update := map[string]interface{}{}
+	if !strings.EqualFold(result.IssueType, issueTypeName(issue)) {
+		if name := editableIssueType(editMeta.Fields, result.IssueType); name != "" {
+			result.IssueType = name
+			update["issuetype"] = map[string]interface{}{"name": name}
+		} else {
+			result.Method = "clone"
+		}
+	}
+	if result.Parent != issueParentKey(issue) {
+		if _, ok := editMeta.Fields["parent"]; ok && result.Parent != "" {
+			update["parent"] = map[string]interface{}{"key": result.Parent}
+		} else if result.Parent != "" || update["issuetype"] == nil {
+			// the parent of an issue can only be removed by changing it to a
+			// standard issue type
+			result.Method = "clone"
+		}
+	}
+
+	cloner := newIssueCloner(o, globals.Endpoint.Value, &CloneOptions{
+		WithSubtasks:    true,
+		WithLinks:       true,
+		WithAttachments: true,
+	})
+	project := issueProjectKey(issue)
+	createMeta, err := cloner.createMeta(project, result.IssueType)
+	if err != nil {
+		return err
+	}
+	subtasks := jiradata.Issues{}
+	if err := jiracli.ConvertType(issue.Fields["subtasks"], &subtasks); err != nil {
+		return err
+	}
+	if createMeta.Subtask && result.Parent == "" {
+		return fmt.Errorf("%s is a subtask issue type, use --issuetype to choose a standard issue type", createMeta.Name)
+	}
+	if createMeta.Subtask && len(subtasks) > 0 {
+		return fmt.Errorf("%s has subtasks and cannot become a subtask", issue.Key)
+	}
+	if result.Method == "clone" {
+		result.Lost = lostFields(issue, editMeta.Fields, createMeta.Fields)
+	}
+
+	if !opts.DryRun {
+		if result.Method == "edit" {
+			if len(update) > 0 {
+				err := jira.EditIssue(o, globals.Endpoint.Value, issue.Key, &jiradata.IssueUpdate{
+					Fields: update,
+				})
+				if err != nil {
+					return err
+				}
+			}
+			result.To = issue.Key
+		} else {
+			if result.To, err = cloner.cloneTree(issue, project, result.IssueType, result.Parent); err != nil {
+				return err
+			}
+			// subtasks have to be closed before their parent
+			for _, subtask := range subtasks {
+				if err := linkAndClose(o, globals.Endpoint.Value, opts.LinkType, opts.CloseTransition, subtask.Key, cloner.keys[subtask.Key]); err != nil {
+					return err
+				}
+			}
+			if err := linkAndClose(o, globals.Endpoint.Value, opts.LinkType, opts.CloseTransition, issue.Key, result.To); err != nil {
+				return err
+			}
+		}
+		result.URL = jira.URLJoin(globals.Endpoint.Value, "browse", result.To)
+	}
+
+	if !globals.Quiet.Value {
+		if err := opts.PrintTemplate(result); err != nil {
+			return err
+		}
+	}
+	if opts.Browse.Value && !opts.DryRun {
+		return CmdBrowse(globals, result.To)
+	}
+	return nil
+}
+
+func reparentUsage(cmd *kingpin.CmdClause, opts *ReparentOptions) {
+	jiracli.BrowseUsage(cmd, &opts.CommonOptions)
+	jiracli.TemplateUsage(cmd, &opts.CommonOptions)
+	jiracli.GJsonQueryUsage(cmd, &opts.CommonOptions)
+	cmd.Flag("link-type", "Link type used to link the issue to its copy when it has to be copied").StringVar(&opts.LinkType)
+	cmd.Flag("close-transition", "Transition used to close the issue after copying it, defaults to the first transition to a done status").StringVar(&opts.CloseTransition)
+	cmd.Flag("dryrun", "Only report how the issue would be changed and the fields that would be lost").BoolVar(&opts.DryRun)
+}
@@ -0,0 +1,204 @@
+package jiracmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	"github.com/go-jira/jira/jiradata"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+// ReportOptions are the options shared by the sprint report commands
+type ReportOptions struct {
+	PointsField string `yaml:"points-field,omitempty" json:"points-field,omitempty"`
+}
+
+func reportUsage(cmd *kingpin.CmdClause, opts *ReportOptions) {
+	cmd.Flag("points-field", "Name or id of the story points field, discovered from the field names by default").StringVar(&opts.PointsField)
+}
+
+// pointsFieldNames are the names Jira Software uses for the story points
+// field, "Story point estimate" is used by next-gen projects.
+var pointsFieldNames = []string{"Story Points", "Story point estimate"}
+
+// findPointsField returns the story points field, looking it up by the
+// configured name or id, or by the default field names.
+func (o *ReportOptions) findPointsField(ua *oreo.Client, endpoint string) (*jiradata.Field, error) {
+	fields, err := jira.GetFields(ua, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	names := pointsFieldNames
+	if o.PointsField != "" {
+		names = []string{o.PointsField}
+	}
+	for _, name := range names {
+		for i, field := range fields {
+			if strings.EqualFold(field.ID, name) || strings.EqualFold(field.Name, name) {
+				return &fields[i], nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("Unable to find story points field %q, use --points-field", strings.Join(names, `" or "`))
+}
+
+// fieldChange is a change to a field found in the changelog
+type fieldChange struct {
+	at   time.Time
+	item *jiradata.ChangeItem
+}
+
+// sprintIssue is an issue with the changelog for the fields used to replay
+// the state of the issue during a sprint.
+type sprintIssue struct {
+	key           string
+	created       time.Time
+	status        *jiradata.Status
+	points        float64
+	statusChanges []fieldChange
+	sprintChanges []fieldChange
+	pointsChanges []fieldChange
+}
+
+// getSprintIssues fetches the issues that are or were in the sprint along
+// with their changelogs.
+func getSprintIssues(o *oreo.Client, endpoint string, sprint int, points *jiradata.Field) ([]*sprintIssue, error) {
+	results, err := jira.Search(o, endpoint, &jira.SearchOptions{
+		Query: fmt.Sprintf("sprint = %d ORDER BY key", sprint),
+	}, jira.WithAutoPagination())
+	if err != nil {
+		return nil, err
+	}
+	issues := []*sprintIssue{}
+	for _, result := range results.Issues {
+		issue, histories, err := getIssueHistories(o, endpoint, result.Key, []string{"status", "created", points.ID})
+		if err != nil {
+			return nil, err
+		}
+		created, err := time.Parse(jiraTimeFormat, fmt.Sprintf("%v", issue.Fields["created"]))
+		if err != nil {
+			return nil, err
+		}
+		si := &sprintIssue{
+			key:     issue.Key,
+			created: created,
+			status:  &jiradata.Status{},
+		}
+		if err := jiracli.ConvertType(issue.Fields["status"], si.status); err != nil {
+			return nil, err
+		}
+		si.points, _ = issue.Fields[points.ID].(float64)
+		for _, history := range histories {
+			at, err := time.Parse(jiraTimeFormat, history.Created)
+			if err != nil {
+				return nil, err
+			}
+			for _, item := range history.Items {
+				change := fieldChange{at: at, item: item}
+				switch {
+				case item.Field == "status":
+					si.statusChanges = append(si.statusChanges, change)
+				case item.Field == "Sprint":
+					si.sprintChanges = append(si.sprintChanges, change)
+				case item.FieldID == points.ID || item.Field == points.Name:
+					si.pointsChanges = append(si.pointsChanges, change)
+				}
+			}
+		}
+		for _, changes := range [][]fieldChange{si.statusChanges, si.sprintChanges, si.pointsChanges} {
+			sort.SliceStable(changes, func(i, j int) bool {
+				return changes[i].at.Before(changes[j].at)
+			})
+		}
+		issues = append(issues, si)
+	}
+	return issues, nil
+}
+
+// valueAt replays the changes to find the value of the field at the time.  ok
+// is false if the field never changed, in which case the current value
+// applies.
+func valueAt(changes []fieldChange, at time.Time) (id, value string, ok bool) {
+	for i := len(changes) - 1; i >= 0; i-- {
+		if !changes[i].at.After(at) {
+			return changes[i].item.To, changes[i].item.ToString, true
+		}
+	}
+	if len(changes) > 0 {
+		return changes[0].item.From, changes[0].item.FromString, true
+	}
+	return "", "", false
+}
+
+// inSprint returns true if the issue was in the sprint at the time.  Issues
+// without changes to the Sprint field have been in the sprint since they were
+// created.
+func (i *sprintIssue) inSprint(sprint int, at time.Time) bool {
+	if i.created.After(at) {
+		return false
+	}
+	ids, _, ok := valueAt(i.sprintChanges, at)
+	if !ok {
+		return true
+	}
+	for _, id := range strings.Split(ids, ",") {
+		if strings.TrimSpace(id) == strconv.Itoa(sprint) {
+			return true
+		}
+	}
+	return false
+}
+
+func (i *sprintIssue) pointsAt(at time.Time) float64 {
+	_, value, ok := valueAt(i.pointsChanges, at)
+	if !ok {
+		return i.points
+	}
+	points, _ := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	return points
+}
+
+func (i *sprintIssue) doneAt(at time.Time, categories map[string]*jiradata.StatusCategory) bool {
+	id, name, ok := valueAt(i.statusChanges, at)
+	if !ok {
+		id, name = i.status.ID, i.status.Name
+	}
+	category, found := categories[id]
+	if !found {
+		category = categories[strings.ToLower(name)]
+	}
+	if category == nil && id == i.status.ID {
+		category = i.status.StatusCategory
+	}
+	return categoryMatches(category, []string{"done"})
+}
+
+// getStatusCategories returns the status categories by status id and by
+// lower case status name.
+func getStatusCategories(o *oreo.Client, endpoint string) (map[string]*jiradata.StatusCategory, error) {
+	statuses, err := jira.GetStatuses(o, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	categories := map[string]*jiradata.StatusCategory{}
+	for _, status := range statuses {
+		categories[status.ID] = status.StatusCategory
+		categories[strings.ToLower(status.Name)] = status.StatusCategory
+	}
+	return categories, nil
+}
+
+// parseSprintDate parses the ISO 8601 dates used by the agile API, returning
+// the zero time for empty dates.
+func parseSprintDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
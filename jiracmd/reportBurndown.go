@@ -0,0 +1,156 @@
+package jiracmd
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type ReportBurndownOptions struct {
+	jiracli.CommonOptions `yaml:",inline" json:",inline" figtree:",inline"`
+	ReportOptions         `yaml:",inline" json:",inline" figtree:",inline"`
+	Sprint                int `yaml:"sprint,omitempty" json:"sprint,omitempty"`
+}
+
+func CmdReportBurndownRegistry() *jiracli.CommandRegistryEntry {
+	opts := ReportBurndownOptions{
+		CommonOptions: jiracli.CommonOptions{
+			Template: figtree.NewStringOption("burndown"),
+		},
+	}
+	return &jiracli.CommandRegistryEntry{
+		"Prints the remaining story points per day of a sprint",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdReportBurndownUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			return CmdReportBurndown(o, globals, &opts)
+		},
+	}
+}
+
+func CmdReportBurndownUsage(cmd *kingpin.CmdClause, opts *ReportBurndownOptions) error {
+	jiracli.TemplateUsage(cmd, &opts.CommonOptions)
+	jiracli.GJsonQueryUsage(cmd, &opts.CommonOptions)
+	reportUsage(cmd, &opts.ReportOptions)
+	cmd.Arg("SPRINT", "id of the sprint").Required().IntVar(&opts.Sprint)
+	return nil
+}
+
+type burndownDay struct {
+	Date      string  `json:"date" yaml:"date"`
+	Remaining float64 `json:"remaining" yaml:"remaining"`
+	Scope     float64 `json:"scope" yaml:"scope"`
+	Ideal     float64 `json:"ideal" yaml:"ideal"`
+}
+
+type burndownReport struct {
+	ID           int            `json:"id" yaml:"id"`
+	Name         string         `json:"name" yaml:"name"`
+	State        string         `json:"state" yaml:"state"`
+	StartDate    string         `json:"startDate" yaml:"startDate"`
+	EndDate      string         `json:"endDate" yaml:"endDate"`
+	CompleteDate string         `json:"completeDate" yaml:"completeDate"`
+	Committed    float64        `json:"committed" yaml:"committed"`
+	Days         []*burndownDay `json:"days" yaml:"days"`
+	// Max is the largest scope or committed value, used to scale charts
+	Max float64 `json:"max" yaml:"max"`
+}
+
+// CmdReportBurndown will replay the changelog of the issues in the sprint to
+// compute the story points remaining at the end of each day of the sprint,
+// then send the report to the "burndown" template.
+func CmdReportBurndown(o *oreo.Client, globals *jiracli.GlobalOptions, opts *ReportBurndownOptions) error {
+	sprint, err := jira.GetSprint(o, globals.Endpoint.Value, strconv.Itoa(opts.Sprint))
+	if err != nil {
+		return err
+	}
+	start, err := parseSprintDate(sprint.StartDate)
+	if err != nil {
+		return err
+	}
+	if start.IsZero() {
+		return fmt.Errorf("Sprint %d has not started", sprint.ID)
+	}
+	end, err := parseSprintDate(sprint.EndDate)
+	if err != nil {
+		return err
+	}
+	complete, err := parseSprintDate(sprint.CompleteDate)
+	if err != nil {
+		return err
+	}
+	// the ideal line runs until the planned end of the sprint, the actual
+	// line until the sprint completed or now
+	planned := end
+	if planned.IsZero() || planned.Before(start) {
+		planned = start
+	}
+	last := time.Now()
+	if !complete.IsZero() {
+		last = complete
+	}
+
+	points, err := opts.findPointsField(o, globals.Endpoint.Value)
+	if err != nil {
+		return err
+	}
+	categories, err := getStatusCategories(o, globals.Endpoint.Value)
+	if err != nil {
+		return err
+	}
+	issues, err := getSprintIssues(o, globals.Endpoint.Value, sprint.ID, points)
+	if err != nil {
+		return err
+	}
+
+	report := &burndownReport{
+		ID:           sprint.ID,
+		Name:         sprint.Name,
+		State:        sprint.State,
+		StartDate:    sprint.StartDate,
+		EndDate:      sprint.EndDate,
+		CompleteDate: sprint.CompleteDate,
+	}
+	for _, issue := range issues {
+		if issue.inSprint(sprint.ID, start) {
+			report.Committed += issue.pointsAt(start)
+		}
+	}
+	report.Max = report.Committed
+
+	start = start.Local()
+	duration := planned.Sub(start)
+	for day := startOfDay(start); !day.After(last); day = day.AddDate(0, 0, 1) {
+		at := day.AddDate(0, 0, 1)
+		if at.After(last) {
+			at = last
+		}
+		row := &burndownDay{Date: day.Format("2006-01-02")}
+		for _, issue := range issues {
+			if !issue.inSprint(sprint.ID, at) {
+				continue
+			}
+			pts := issue.pointsAt(at)
+			row.Scope += pts
+			if !issue.doneAt(at, categories) {
+				row.Remaining += pts
+			}
+		}
+		if duration > 0 {
+			fraction := math.Min(1, math.Max(0, float64(at.Sub(start))/float64(duration)))
+			row.Ideal = math.Round(report.Committed*(1-fraction)*10) / 10
+		}
+		report.Max = math.Max(report.Max, row.Scope)
+		report.Days = append(report.Days, row)
+	}
+	return opts.PrintTemplate(report)
+}
@@ -0,0 +1,133 @@
+package jiracmd
+
+import (
+	"math"
+	"sort"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type ReportVelocityOptions struct {
+	jiracli.CommonOptions `yaml:",inline" json:",inline" figtree:",inline"`
+	ReportOptions         `yaml:",inline" json:",inline" figtree:",inline"`
+	Board                 string `yaml:"board,omitempty" json:"board,omitempty"`
+	Sprints               int    `yaml:"sprints,omitempty" json:"sprints,omitempty"`
+}
+
+func CmdReportVelocityRegistry() *jiracli.CommandRegistryEntry {
+	opts := ReportVelocityOptions{
+		CommonOptions: jiracli.CommonOptions{
+			Template: figtree.NewStringOption("velocity"),
+		},
+	}
+	return &jiracli.CommandRegistryEntry{
+		"Prints committed and completed story points for the closed sprints of a board",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdReportVelocityUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			if opts.Sprints == 0 {
+				opts.Sprints = 7
+			}
+			return CmdReportVelocity(o, globals, &opts)
+		},
+	}
+}
+
+func CmdReportVelocityUsage(cmd *kingpin.CmdClause, opts *ReportVelocityOptions) error {
+	jiracli.TemplateUsage(cmd, &opts.CommonOptions)
+	jiracli.GJsonQueryUsage(cmd, &opts.CommonOptions)
+	reportUsage(cmd, &opts.ReportOptions)
+	cmd.Flag("sprints", "Number of most recently closed sprints to report, defaults to 7").IntVar(&opts.Sprints)
+	cmd.Arg("BOARD", "id of the board").Required().StringVar(&opts.Board)
+	return nil
+}
+
+type velocitySprint struct {
+	ID              int     `json:"id" yaml:"id"`
+	Name            string  `json:"name" yaml:"name"`
+	StartDate       string  `json:"startDate" yaml:"startDate"`
+	CompleteDate    string  `json:"completeDate" yaml:"completeDate"`
+	Committed       float64 `json:"committed" yaml:"committed"`
+	Completed       float64 `json:"completed" yaml:"completed"`
+	CommittedIssues int     `json:"committedIssues" yaml:"committedIssues"`
+	CompletedIssues int     `json:"completedIssues" yaml:"completedIssues"`
+}
+
+type velocityReport struct {
+	Board   string            `json:"board" yaml:"board"`
+	Sprints []*velocitySprint `json:"sprints" yaml:"sprints"`
+	Average float64           `json:"average" yaml:"average"`
+	// Max is the largest committed or completed value, used to scale charts
+	Max float64 `json:"max" yaml:"max"`
+}
+
+// CmdReportVelocity will replay the changelog of the issues in each closed
+// sprint to find the story points committed when the sprint started and the
+// story points completed when the sprint closed, then send the report to the
+// "velocity" template.
+func CmdReportVelocity(o *oreo.Client, globals *jiracli.GlobalOptions, opts *ReportVelocityOptions) error {
+	points, err := opts.findPointsField(o, globals.Endpoint.Value)
+	if err != nil {
+		return err
+	}
+	categories, err := getStatusCategories(o, globals.Endpoint.Value)
+	if err != nil {
+		return err
+	}
+	sprints, err := jira.GetBoardSprints(o, globals.Endpoint.Value, opts.Board, "closed")
+	if err != nil {
+		return err
+	}
+	closed := *sprints
+	sort.SliceStable(closed, func(i, j int) bool {
+		return closed[i].CompleteDate < closed[j].CompleteDate
+	})
+	if len(closed) > opts.Sprints {
+		closed = closed[len(closed)-opts.Sprints:]
+	}
+
+	report := &velocityReport{Board: opts.Board}
+	for _, sprint := range closed {
+		start, err := parseSprintDate(sprint.StartDate)
+		if err != nil {
+			return err
+		}
+		complete, err := parseSprintDate(sprint.CompleteDate)
+		if err != nil {
+			return err
+		}
+		issues, err := getSprintIssues(o, globals.Endpoint.Value, sprint.ID, points)
+		if err != nil {
+			return err
+		}
+		row := &velocitySprint{
+			ID:           sprint.ID,
+			Name:         sprint.Name,
+			StartDate:    sprint.StartDate,
+			CompleteDate: sprint.CompleteDate,
+		}
+		for _, issue := range issues {
+			if issue.inSprint(sprint.ID, start) {
+				row.Committed += issue.pointsAt(start)
+				row.CommittedIssues++
+			}
+			if issue.inSprint(sprint.ID, complete) && issue.doneAt(complete, categories) {
+				row.Completed += issue.pointsAt(complete)
+				row.CompletedIssues++
+			}
+		}
+		report.Average += row.Completed
+		report.Max = math.Max(report.Max, math.Max(row.Committed, row.Completed))
+		report.Sprints = append(report.Sprints, row)
+	}
+	if len(report.Sprints) > 0 {
+		report.Average = math.Round(report.Average/float64(len(report.Sprints))*10) / 10
+	}
+	return opts.PrintTemplate(report)
+}
@@ -0,0 +1,131 @@
+package jiracmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-jira/jira/jiradata"
+	"github.com/stretchr/testify/assert"
+)
+
+// reportDay returns the time of the day of the sprint, which starts on day 1.
+func reportDay(day int) time.Time {
+	return time.Date(2020, 3, day, 12, 0, 0, 0, time.UTC)
+}
+
+func reportChange(day int, from, fromString, to, toString string) fieldChange {
+	return fieldChange{
+		at:   reportDay(day),
+		item: &jiradata.ChangeItem{From: from, FromString: fromString, To: to, ToString: toString},
+	}
+}
+
+func TestValueAt(t *testing.T) {
+	changes := []fieldChange{
+		reportChange(2, "1", "Open", "2", "In Progress"),
+		reportChange(4, "2", "In Progress", "3", "Done"),
+	}
+	for _, test := range []struct {
+		day   int
+		id    string
+		value string
+	}{
+		// before the first change the field had the original value
+		{1, "1", "Open"},
+		{2, "2", "In Progress"},
+		{3, "2", "In Progress"},
+		{5, "3", "Done"},
+	} {
+		id, value, ok := valueAt(changes, reportDay(test.day))
+		assert.True(t, ok)
+		assert.Equal(t, test.id, id, "day %d", test.day)
+		assert.Equal(t, test.value, value, "day %d", test.day)
+	}
+
+	_, _, ok := valueAt(nil, reportDay(1))
+	assert.False(t, ok)
+}
+
+func TestSprintIssueInSprint(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		issue    *sprintIssue
+		inSprint []bool
+	}{{
+		"in the sprint from the start",
+		&sprintIssue{created: reportDay(0)},
+		[]bool{true, true, true, true, true},
+	}, {
+		"created mid sprint",
+		&sprintIssue{created: reportDay(3)},
+		[]bool{false, false, true, true, true},
+	}, {
+		"added mid sprint",
+		&sprintIssue{created: reportDay(0), sprintChanges: []fieldChange{
+			reportChange(3, "", "", "7", "Sprint 7"),
+		}},
+		[]bool{false, false, true, true, true},
+	}, {
+		"removed before the end",
+		&sprintIssue{created: reportDay(0), sprintChanges: []fieldChange{
+			reportChange(4, "7", "Sprint 7", "8", "Sprint 8"),
+		}},
+		[]bool{true, true, true, false, false},
+	}, {
+		"carried over from the previous sprint",
+		&sprintIssue{created: reportDay(0), sprintChanges: []fieldChange{
+			reportChange(2, "6", "Sprint 6", "6, 7", "Sprint 6, Sprint 7"),
+		}},
+		[]bool{false, true, true, true, true},
+	}} {
+		for day, expected := range test.inSprint {
+			assert.Equal(t, expected, test.issue.inSprint(7, reportDay(day+1)), "%s on day %d", test.name, day+1)
+		}
+	}
+}
+
+func TestSprintIssuePointsAt(t *testing.T) {
+	issue := &sprintIssue{points: 8}
+	assert.Equal(t, 8.0, issue.pointsAt(reportDay(1)))
+
+	// the points were changed after the start of the sprint
+	issue.pointsChanges = []fieldChange{
+		reportChange(2, "", "3", "", "5"),
+		reportChange(4, "", "5", "", "8"),
+	}
+	assert.Equal(t, 3.0, issue.pointsAt(reportDay(1)))
+	assert.Equal(t, 5.0, issue.pointsAt(reportDay(3)))
+	assert.Equal(t, 8.0, issue.pointsAt(reportDay(5)))
+
+	// the points were removed
+	issue.pointsChanges = []fieldChange{reportChange(2, "", "3", "", "")}
+	assert.Equal(t, 0.0, issue.pointsAt(reportDay(3)))
+}
+
+func TestSprintIssueDoneAt(t *testing.T) {
+	done := &jiradata.StatusCategory{Key: "done", Name: "Done"}
+	todo := &jiradata.StatusCategory{Key: "new", Name: "To Do"}
+	categories := map[string]*jiradata.StatusCategory{
+		"1": todo, "open": todo,
+		"3": done, "done": done,
+	}
+
+	// done and then reopened
+	issue := &sprintIssue{
+		status: &jiradata.Status{ID: "1", Name: "Open"},
+		statusChanges: []fieldChange{
+			reportChange(2, "1", "Open", "3", "Done"),
+			reportChange(4, "3", "Done", "1", "Open"),
+		},
+	}
+	for day, expected := range []bool{false, true, true, false, false} {
+		assert.Equal(t, expected, issue.doneAt(reportDay(day+1), categories), "day %d", day+1)
+	}
+
+	// without status changes the current status applies, statuses unknown
+	// by id are looked up by name and then use the category of the status
+	assert.True(t, (&sprintIssue{status: &jiradata.Status{ID: "3"}}).doneAt(reportDay(1), categories))
+	assert.True(t, (&sprintIssue{status: &jiradata.Status{ID: "9", Name: "Done"}}).doneAt(reportDay(1), categories))
+	assert.True(t, (&sprintIssue{status: &jiradata.Status{ID: "10", StatusCategory: done}}).doneAt(reportDay(1), categories))
+	assert.False(t, (&sprintIssue{status: &jiradata.Status{ID: "11"}}).doneAt(reportDay(1), categories))
+}
@@ -49,6 +49,7 @@ func CmdSubtaskRegistry() *jiracli.CommandRegistryEntry {
 func CmdSubtaskUsage(cmd *kingpin.CmdClause, opts *SubtaskOptions) error {
 	jiracli.BrowseUsage(cmd, &opts.CommonOptions)
 	jiracli.EditorUsage(cmd, &opts.CommonOptions)
+	jiracli.MarkdownUsage(cmd, &opts.CommonOptions)
 	jiracli.TemplateUsage(cmd, &opts.CommonOptions)
 	cmd.Flag("noedit", "Disable opening the editor").SetValue(&opts.SkipEditing)
 	cmd.Flag("project", "project to subtask issue in").Short('p').StringVar(&opts.Project)
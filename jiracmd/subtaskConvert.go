@@ -0,0 +1,39 @@
+package jiracmd
+
+import (
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira/jiracli"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+func CmdSubtaskConvertRegistry() *jiracli.CommandRegistryEntry {
+	opts := ReparentOptions{
+		CommonOptions: jiracli.CommonOptions{
+			Template: figtree.NewStringOption("reparent"),
+		},
+		IssueType: "Sub-task",
+		LinkType:  "Cloners",
+	}
+
+	return &jiracli.CommandRegistryEntry{
+		"Convert an issue to a subtask of another issue",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdSubtaskConvertUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			opts.Issue = jiracli.FormatIssue(opts.Issue, opts.Project)
+			opts.Parent = jiracli.FormatIssue(opts.Parent, opts.Project)
+			return CmdReparent(o, globals, &opts)
+		},
+	}
+}
+
+func CmdSubtaskConvertUsage(cmd *kingpin.CmdClause, opts *ReparentOptions) error {
+	reparentUsage(cmd, opts)
+	cmd.Flag("parent", "Parent issue of the subtask").Required().StringVar(&opts.Parent)
+	cmd.Flag("issuetype", "Subtask issue type to convert the issue to").StringVar(&opts.IssueType)
+	cmd.Arg("ISSUE", "issue to convert").Required().StringVar(&opts.Issue)
+	return nil
+}
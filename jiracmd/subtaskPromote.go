@@ -0,0 +1,37 @@
+package jiracmd
+
+import (
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira/jiracli"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+func CmdSubtaskPromoteRegistry() *jiracli.CommandRegistryEntry {
+	opts := ReparentOptions{
+		CommonOptions: jiracli.CommonOptions{
+			Template: figtree.NewStringOption("reparent"),
+		},
+		IssueType: "Task",
+		LinkType:  "Cloners",
+	}
+
+	return &jiracli.CommandRegistryEntry{
+		"Convert a subtask to a standard issue",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdSubtaskPromoteUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			opts.Issue = jiracli.FormatIssue(opts.Issue, opts.Project)
+			return CmdReparent(o, globals, &opts)
+		},
+	}
+}
+
+func CmdSubtaskPromoteUsage(cmd *kingpin.CmdClause, opts *ReparentOptions) error {
+	reparentUsage(cmd, opts)
+	cmd.Flag("issuetype", "Standard issue type to convert the subtask to").StringVar(&opts.IssueType)
+	cmd.Arg("SUBTASK", "subtask to convert").Required().StringVar(&opts.Issue)
+	return nil
+}
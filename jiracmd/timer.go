@@ -0,0 +1,135 @@
+package jiracmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	"github.com/go-jira/jira/jiradata"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+	yaml "gopkg.in/coryb/yaml.v2"
+)
+
+// TimerOptions are the options shared by the timer commands
+type TimerOptions struct {
+	Granularity string `yaml:"timer-granularity,omitempty" json:"timer-granularity,omitempty"`
+}
+
+// timerState is the running timer persisted in ~/.jira.d/timer.yml
+type timerState struct {
+	Issue   string `yaml:"issue,omitempty" json:"issue,omitempty"`
+	Started string `yaml:"started,omitempty" json:"started,omitempty"`
+	Comment string `yaml:"comment,omitempty" json:"comment,omitempty"`
+}
+
+func timerUsage(cmd *kingpin.CmdClause, opts *TimerOptions) {
+	cmd.Flag("granularity", "Round elapsed time up to a multiple of this duration (ie: 1m, 15m)").StringVar(&opts.Granularity)
+}
+
+func timerStateFile() string {
+	return filepath.Join(jiracli.Homedir(), ".jira.d", "timer.yml")
+}
+
+// loadTimer returns the running timer, or nil if there is none
+func loadTimer() (*timerState, error) {
+	data, err := ioutil.ReadFile(timerStateFile())
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	state := &timerState{}
+	if err := yaml.Unmarshal(data, state); err != nil {
+		return nil, err
+	}
+	if state.Issue == "" {
+		return nil, nil
+	}
+	return state, nil
+}
+
+func saveTimer(state *timerState) error {
+	if err := os.MkdirAll(filepath.Dir(timerStateFile()), 0755); err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(timerStateFile(), data, 0600)
+}
+
+func clearTimer() error {
+	if err := os.Remove(timerStateFile()); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// elapsed returns the time since the timer started, rounded up to
+// granularity.  The result is never less than granularity, or a minute, since
+// Jira rejects worklogs under a minute.
+func (s *timerState) elapsed(granularity time.Duration) (time.Duration, error) {
+	started, err := time.Parse(jiraTimeFormat, s.Started)
+	if err != nil {
+		return 0, err
+	}
+	d := time.Since(started)
+	if granularity > 0 {
+		d = (d + granularity - 1) / granularity * granularity
+		if d < granularity {
+			d = granularity
+		}
+	}
+	if d < time.Minute {
+		d = time.Minute
+	}
+	return d, nil
+}
+
+func (o *TimerOptions) granularity() (time.Duration, error) {
+	if o.Granularity == "" {
+		return time.Minute, nil
+	}
+	d, err := time.ParseDuration(o.Granularity)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid timer granularity %q: %s", o.Granularity, err)
+	}
+	return d, nil
+}
+
+// stopTimer submits the elapsed time of the running timer as a worklog and
+// removes the timer state.
+func stopTimer(o *oreo.Client, globals *jiracli.GlobalOptions, state *timerState, opts *TimerOptions, comment string) error {
+	granularity, err := opts.granularity()
+	if err != nil {
+		return err
+	}
+	elapsed, err := state.elapsed(granularity)
+	if err != nil {
+		return err
+	}
+	if comment == "" {
+		comment = state.Comment
+	}
+	worklog := jiradata.Worklog{
+		Comment:          comment,
+		Started:          state.Started,
+		TimeSpentSeconds: int(elapsed.Seconds()),
+	}
+	if _, err := jira.AddIssueWorklog(o, globals.Endpoint.Value, state.Issue, &worklog); err != nil {
+		return err
+	}
+	if err := clearTimer(); err != nil {
+		return err
+	}
+	if !globals.Quiet.Value {
+		fmt.Printf("OK %s logged %s %s\n", state.Issue, formatSeconds(worklog.TimeSpentSeconds), jira.URLJoin(globals.Endpoint.Value, "browse", state.Issue))
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package jiracmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type TimerStartOptions struct {
+	TimerOptions `yaml:",inline" json:",inline" figtree:",inline"`
+	Project      string `yaml:"project,omitempty" json:"project,omitempty"`
+	Issue        string `yaml:"issue,omitempty" json:"issue,omitempty"`
+	Comment      string `yaml:"comment,omitempty" json:"comment,omitempty"`
+}
+
+func CmdTimerStartRegistry() *jiracli.CommandRegistryEntry {
+	opts := TimerStartOptions{}
+
+	return &jiracli.CommandRegistryEntry{
+		"Start a work timer for an issue, stopping any running timer",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdTimerStartUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			opts.Issue = jiracli.FormatIssue(opts.Issue, opts.Project)
+			return CmdTimerStart(o, globals, &opts)
+		},
+	}
+}
+
+func CmdTimerStartUsage(cmd *kingpin.CmdClause, opts *TimerStartOptions) error {
+	timerUsage(cmd, &opts.TimerOptions)
+	cmd.Flag("comment", "Comment message for the worklog when the timer is stopped").Short('m').StringVar(&opts.Comment)
+	cmd.Arg("ISSUE", "issue id to track time for").Required().StringVar(&opts.Issue)
+	return nil
+}
+
+// CmdTimerStart will stop the running timer (submitting its worklog) and then
+// start a new timer for the issue.
+func CmdTimerStart(o *oreo.Client, globals *jiracli.GlobalOptions, opts *TimerStartOptions) error {
+	// verify the issue exists before we stop the running timer
+	if _, err := jira.GetIssue(o, globals.Endpoint.Value, opts.Issue, &jira.IssueOptions{Fields: []string{"summary"}}); err != nil {
+		return err
+	}
+
+	running, err := loadTimer()
+	if err != nil {
+		return err
+	}
+	if running != nil {
+		if running.Issue == opts.Issue {
+			return fmt.Errorf("Timer already running for %s since %s", running.Issue, running.Started)
+		}
+		if err := stopTimer(o, globals, running, &opts.TimerOptions, ""); err != nil {
+			return err
+		}
+	}
+
+	state := &timerState{
+		Issue:   opts.Issue,
+		Started: time.Now().Format(jiraTimeFormat),
+		Comment: opts.Comment,
+	}
+	if err := saveTimer(state); err != nil {
+		return err
+	}
+	if !globals.Quiet.Value {
+		fmt.Printf("OK %s timer started %s\n", opts.Issue, jira.URLJoin(globals.Endpoint.Value, "browse", opts.Issue))
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package jiracmd
+
+import (
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira/jiracli"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type TimerStatusOptions struct {
+	jiracli.CommonOptions `yaml:",inline" json:",inline" figtree:",inline"`
+	TimerOptions          `yaml:",inline" json:",inline" figtree:",inline"`
+}
+
+func CmdTimerStatusRegistry() *jiracli.CommandRegistryEntry {
+	opts := TimerStatusOptions{
+		CommonOptions: jiracli.CommonOptions{
+			Template: figtree.NewStringOption("timer"),
+		},
+	}
+
+	return &jiracli.CommandRegistryEntry{
+		"Prints the running work timer",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdTimerStatusUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			return CmdTimerStatus(o, globals, &opts)
+		},
+	}
+}
+
+func CmdTimerStatusUsage(cmd *kingpin.CmdClause, opts *TimerStatusOptions) error {
+	jiracli.TemplateUsage(cmd, &opts.CommonOptions)
+	jiracli.GJsonQueryUsage(cmd, &opts.CommonOptions)
+	timerUsage(cmd, &opts.TimerOptions)
+	return nil
+}
+
+// CmdTimerStatus will send the running timer to the "timer" template
+func CmdTimerStatus(o *oreo.Client, globals *jiracli.GlobalOptions, opts *TimerStatusOptions) error {
+	running, err := loadTimer()
+	if err != nil {
+		return err
+	}
+	data := struct {
+		*timerState `yaml:",inline"`
+		Elapsed     string `yaml:"elapsed,omitempty" json:"elapsed,omitempty"`
+		Logged      string `yaml:"logged,omitempty" json:"logged,omitempty"`
+	}{timerState: running}
+	if running != nil {
+		granularity, err := opts.granularity()
+		if err != nil {
+			return err
+		}
+		elapsed, err := running.elapsed(0)
+		if err != nil {
+			return err
+		}
+		logged, err := running.elapsed(granularity)
+		if err != nil {
+			return err
+		}
+		data.Elapsed = formatSeconds(int(elapsed.Seconds()))
+		data.Logged = formatSeconds(int(logged.Seconds()))
+	}
+	return opts.PrintTemplate(data)
+}
@@ -0,0 +1,48 @@
+package jiracmd
+
+import (
+	"fmt"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira/jiracli"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type TimerStopOptions struct {
+	TimerOptions `yaml:",inline" json:",inline" figtree:",inline"`
+	Comment      string `yaml:"comment,omitempty" json:"comment,omitempty"`
+}
+
+func CmdTimerStopRegistry() *jiracli.CommandRegistryEntry {
+	opts := TimerStopOptions{}
+
+	return &jiracli.CommandRegistryEntry{
+		"Stop the running work timer and log the elapsed time",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdTimerStopUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			return CmdTimerStop(o, globals, &opts)
+		},
+	}
+}
+
+func CmdTimerStopUsage(cmd *kingpin.CmdClause, opts *TimerStopOptions) error {
+	timerUsage(cmd, &opts.TimerOptions)
+	cmd.Flag("comment", "Comment message for worklog").Short('m').StringVar(&opts.Comment)
+	return nil
+}
+
+// CmdTimerStop will submit the elapsed time of the running timer as a worklog
+func CmdTimerStop(o *oreo.Client, globals *jiracli.GlobalOptions, opts *TimerStopOptions) error {
+	running, err := loadTimer()
+	if err != nil {
+		return err
+	}
+	if running == nil {
+		return fmt.Errorf("No timer running")
+	}
+	return stopTimer(o, globals, running, &opts.TimerOptions, opts.Comment)
+}
@@ -0,0 +1,50 @@
+package jiracmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimerElapsed(t *testing.T) {
+	for _, test := range []struct {
+		name        string
+		ago         time.Duration
+		granularity time.Duration
+		elapsed     time.Duration
+	}{
+		{"rounded up to a minute", 61 * time.Second, time.Minute, 2 * time.Minute},
+		{"rounded up to 15 minutes", 7*time.Minute + 30*time.Second, 15 * time.Minute, 15 * time.Minute},
+		{"past a multiple", 16 * time.Minute, 15 * time.Minute, 30 * time.Minute},
+		{"minimum of a minute", 10 * time.Second, 0, time.Minute},
+		{"minimum of a minute over granularity", 10 * time.Second, 30 * time.Second, time.Minute},
+		{"minimum of granularity", -time.Minute, 15 * time.Minute, 15 * time.Minute},
+	} {
+		state := &timerState{Issue: "ABC-1", Started: time.Now().Add(-test.ago).Format(jiraTimeFormat)}
+		elapsed, err := state.elapsed(test.granularity)
+		assert.NoError(t, err, test.name)
+		assert.Equal(t, test.elapsed, elapsed, test.name)
+	}
+
+	state := &timerState{Issue: "ABC-1", Started: time.Now().Add(-2 * time.Minute).Format(jiraTimeFormat)}
+	elapsed, err := state.elapsed(0)
+	assert.NoError(t, err)
+	assert.InDelta(t, float64(2*time.Minute), float64(elapsed), float64(time.Second))
+
+	_, err = (&timerState{Issue: "ABC-1", Started: "yesterday"}).elapsed(time.Minute)
+	assert.Error(t, err)
+}
+
+func TestTimerGranularity(t *testing.T) {
+	granularity, err := (&TimerOptions{}).granularity()
+	assert.NoError(t, err)
+	assert.Equal(t, time.Minute, granularity)
+
+	granularity, err = (&TimerOptions{Granularity: "15m"}).granularity()
+	assert.NoError(t, err)
+	assert.Equal(t, 15*time.Minute, granularity)
+
+	_, err = (&TimerOptions{Granularity: "15"}).granularity()
+	assert.Contains(t, err.Error(), `Invalid timer granularity "15"`)
+}
@@ -0,0 +1,287 @@
+package jiracmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	"github.com/go-jira/jira/jiradata"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type TimesheetOptions struct {
+	jiracli.CommonOptions `yaml:",inline" json:",inline" figtree:",inline"`
+	Author                string `yaml:"author,omitempty" json:"author,omitempty"`
+	From                  string `yaml:"from,omitempty" json:"from,omitempty"`
+	To                    string `yaml:"to,omitempty" json:"to,omitempty"`
+	Project               string `yaml:"project,omitempty" json:"project,omitempty"`
+}
+
+func CmdTimesheetRegistry() *jiracli.CommandRegistryEntry {
+	opts := TimesheetOptions{
+		CommonOptions: jiracli.CommonOptions{
+			Template: figtree.NewStringOption("timesheet"),
+		},
+	}
+
+	return &jiracli.CommandRegistryEntry{
+		"Prints time logged per day and issue for a user",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdTimesheetUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			if opts.Author == "" {
+				opts.Author = globals.User.Value
+			}
+			if opts.From == "" {
+				opts.From = "7d"
+			}
+			return CmdTimesheet(o, globals, &opts)
+		},
+	}
+}
+
+func CmdTimesheetUsage(cmd *kingpin.CmdClause, opts *TimesheetOptions) error {
+	jiracli.TemplateUsage(cmd, &opts.CommonOptions)
+	jiracli.GJsonQueryUsage(cmd, &opts.CommonOptions)
+	cmd.Flag("author", "User to report worklogs for, defaults to the global --user login (used instead of --user, which is taken by the global flag)").StringVar(&opts.Author)
+	cmd.Flag("from", "First day of report (YYYY-MM-DD) or duration ago (ie: 7d), defaults to 7d").StringVar(&opts.From)
+	cmd.Flag("to", "Last day of report (YYYY-MM-DD) or duration ago, defaults to today").StringVar(&opts.To)
+	cmd.Flag("project", "Only report worklogs for issues in project").Short('p').StringVar(&opts.Project)
+	return nil
+}
+
+type timesheetDay struct {
+	Date      string `json:"date" yaml:"date"`
+	Label     string `json:"label" yaml:"label"`
+	Seconds   int    `json:"seconds" yaml:"seconds"`
+	TimeSpent string `json:"timeSpent" yaml:"timeSpent"`
+}
+
+type timesheetIssue struct {
+	Key       string          `json:"key" yaml:"key"`
+	Summary   string          `json:"summary" yaml:"summary"`
+	Days      []*timesheetDay `json:"days" yaml:"days"`
+	Seconds   int             `json:"seconds" yaml:"seconds"`
+	TimeSpent string          `json:"timeSpent" yaml:"timeSpent"`
+}
+
+type timesheetWorklog struct {
+	Date             string `json:"date" yaml:"date"`
+	Issue            string `json:"issue" yaml:"issue"`
+	Summary          string `json:"summary" yaml:"summary"`
+	Started          string `json:"started" yaml:"started"`
+	TimeSpentSeconds int    `json:"timeSpentSeconds" yaml:"timeSpentSeconds"`
+	TimeSpent        string `json:"timeSpent" yaml:"timeSpent"`
+	Comment          string `json:"comment" yaml:"comment"`
+}
+
+type timesheet struct {
+	User      string              `json:"user" yaml:"user"`
+	From      string              `json:"from" yaml:"from"`
+	To        string              `json:"to" yaml:"to"`
+	Days      []*timesheetDay     `json:"days" yaml:"days"`
+	Issues    []*timesheetIssue   `json:"issues" yaml:"issues"`
+	Worklogs  []*timesheetWorklog `json:"worklogs" yaml:"worklogs"`
+	Seconds   int                 `json:"seconds" yaml:"seconds"`
+	TimeSpent string              `json:"timeSpent" yaml:"timeSpent"`
+	from      time.Time
+	end       time.Time
+	dayIndex  map[string]int
+}
+
+// newTimesheet returns an empty timesheet with a column for each day from
+// the start of from until end, which is exclusive.
+func newTimesheet(user string, from, end time.Time) *timesheet {
+	sheet := &timesheet{
+		User:     user,
+		From:     from.Format("2006-01-02"),
+		To:       end.AddDate(0, 0, -1).Format("2006-01-02"),
+		from:     from,
+		end:      end,
+		dayIndex: map[string]int{},
+	}
+	for day := from; day.Before(end); day = day.AddDate(0, 0, 1) {
+		sheet.dayIndex[day.Format("2006-01-02")] = len(sheet.Days)
+		sheet.Days = append(sheet.Days, &timesheetDay{
+			Date:  day.Format("2006-01-02"),
+			Label: day.Format("Mon 01-02"),
+		})
+	}
+	return sheet
+}
+
+// addIssue adds the worklogs of the issue by any of the authors in the date
+// range to the days of the issue and the timesheet, issues without matching
+// worklogs are left out.
+func (s *timesheet) addIssue(key, summary string, worklogs jiradata.Worklogs, authors ...string) error {
+	row := &timesheetIssue{
+		Key:     key,
+		Summary: summary,
+	}
+	for _, day := range s.Days {
+		row.Days = append(row.Days, &timesheetDay{Date: day.Date, Label: day.Label})
+	}
+	for _, worklog := range worklogs {
+		matches := false
+		for _, author := range authors {
+			matches = matches || userMatches(worklog.Author, author)
+		}
+		if !matches {
+			continue
+		}
+		started, err := time.Parse(jiraTimeFormat, worklog.Started)
+		if err != nil {
+			return err
+		}
+		started = started.Local()
+		if started.Before(s.from) || !started.Before(s.end) {
+			continue
+		}
+		date := started.Format("2006-01-02")
+		i := s.dayIndex[date]
+		row.Days[i].Seconds += worklog.TimeSpentSeconds
+		row.Seconds += worklog.TimeSpentSeconds
+		s.Days[i].Seconds += worklog.TimeSpentSeconds
+		s.Seconds += worklog.TimeSpentSeconds
+		s.Worklogs = append(s.Worklogs, &timesheetWorklog{
+			Date:             date,
+			Issue:            key,
+			Summary:          summary,
+			Started:          worklog.Started,
+			TimeSpentSeconds: worklog.TimeSpentSeconds,
+			TimeSpent:        formatSeconds(worklog.TimeSpentSeconds),
+			Comment:          worklog.Comment,
+		})
+	}
+	if row.Seconds == 0 {
+		return nil
+	}
+	for _, day := range row.Days {
+		day.TimeSpent = formatSeconds(day.Seconds)
+	}
+	row.TimeSpent = formatSeconds(row.Seconds)
+	s.Issues = append(s.Issues, row)
+	return nil
+}
+
+// total formats the daily and overall totals and sorts the worklogs by
+// start time.
+func (s *timesheet) total() {
+	for _, day := range s.Days {
+		day.TimeSpent = formatSeconds(day.Seconds)
+	}
+	s.TimeSpent = formatSeconds(s.Seconds)
+	sort.SliceStable(s.Worklogs, func(i, j int) bool {
+		return s.Worklogs[i].Started < s.Worklogs[j].Started
+	})
+}
+
+// CmdTimesheet will search for issues with worklogs by the user in the date
+// range, then aggregate the matching worklogs per day and issue and send the
+// results to the "timesheet" template.
+func CmdTimesheet(o *oreo.Client, globals *jiracli.GlobalOptions, opts *TimesheetOptions) error {
+	from, err := parseTimeFlag(opts.From)
+	if err != nil {
+		return err
+	}
+	to := time.Now()
+	if opts.To != "" {
+		if to, err = parseTimeFlag(opts.To); err != nil {
+			return err
+		}
+	}
+	from = startOfDay(from)
+	// make the end of the range exclusive at midnight after the last day
+	end := startOfDay(to).AddDate(0, 0, 1)
+	if !end.After(from) {
+		return fmt.Errorf("--to %s is before --from %s", opts.To, opts.From)
+	}
+
+	user, err := resolveUser(o, globals, opts.Author)
+	if err != nil {
+		return err
+	}
+
+	jql := fmt.Sprintf("worklogAuthor = %q AND worklogDate >= '%s' AND worklogDate < '%s'", user, from.Format("2006-01-02"), end.Format("2006-01-02"))
+	if opts.Project != "" {
+		jql = fmt.Sprintf("project = %q AND %s", opts.Project, jql)
+	}
+	results, err := jira.Search(o, globals.Endpoint.Value, &jira.SearchOptions{Query: jql + " ORDER BY key"}, jira.WithAutoPagination())
+	if err != nil {
+		return err
+	}
+
+	sheet := newTimesheet(opts.Author, from, end)
+	for _, issue := range results.Issues {
+		worklogs, err := jira.GetIssueWorklog(o, globals.Endpoint.Value, issue.Key)
+		if err != nil {
+			return err
+		}
+		summary, _ := issue.Fields["summary"].(string)
+		if err := sheet.addIssue(issue.Key, summary, *worklogs, user, opts.Author); err != nil {
+			return err
+		}
+	}
+	sheet.total()
+
+	return opts.PrintTemplate(sheet)
+}
+
+// resolveUser will return the identifier to use for the user in JQL queries.
+// For cloud deployments this is the accountId, otherwise the user name is
+// used as is.
+func resolveUser(o *oreo.Client, globals *jiracli.GlobalOptions, user string) (string, error) {
+	if globals.JiraDeploymentType.Value == "" {
+		serverInfo, err := jira.ServerInfo(o, globals.Endpoint.Value)
+		if err != nil {
+			return "", err
+		}
+		globals.JiraDeploymentType.Value = strings.ToLower(serverInfo.DeploymentType)
+	}
+	if globals.JiraDeploymentType.Value != jiracli.CloudDeploymentType {
+		return user, nil
+	}
+	users, err := jira.UserSearch(o, globals.Endpoint.Value, &jira.UserSearchOptions{
+		Query: user,
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(users) != 1 {
+		return "", fmt.Errorf("Found %d accounts for users with query %q", len(users), user)
+	}
+	return users[0].AccountID, nil
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// formatSeconds returns the duration in the Jira time tracking format, ie
+// "1h 30m".  Days and weeks are not used since their length depends on the
+// Jira time tracking configuration.
+func formatSeconds(seconds int) string {
+	if seconds <= 0 {
+		return ""
+	}
+	hours := seconds / 3600
+	minutes := (seconds % 3600) / 60
+	parts := []string{}
+	if hours > 0 {
+		parts = append(parts, fmt.Sprintf("%dh", hours))
+	}
+	if minutes > 0 {
+		parts = append(parts, fmt.Sprintf("%dm", minutes))
+	}
+	if len(parts) == 0 {
+		return fmt.Sprintf("%ds", seconds)
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,102 @@
+package jiracmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-jira/jira/jiradata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatSeconds(t *testing.T) {
+	for _, test := range []struct {
+		seconds  int
+		expected string
+	}{
+		{-60, ""},
+		{0, ""},
+		{30, "30s"},
+		{60, "1m"},
+		{90, "1m"},
+		{3600, "1h"},
+		{5400, "1h 30m"},
+		{8 * 3600, "8h"},
+		{50*3600 + 60, "50h 1m"},
+	} {
+		assert.Equal(t, test.expected, formatSeconds(test.seconds), "%d", test.seconds)
+	}
+}
+
+func TestTimesheetAddIssue(t *testing.T) {
+	from := time.Date(2020, 3, 2, 0, 0, 0, 0, time.Local)
+	end := from.AddDate(0, 0, 3)
+	worklog := func(day, hour int, author string, seconds int) *jiradata.Worklog {
+		return &jiradata.Worklog{
+			Author:           &jiradata.User{Name: author, AccountID: author + "-id"},
+			Started:          time.Date(2020, 3, day, hour, 0, 0, 0, time.Local).Format(jiraTimeFormat),
+			TimeSpentSeconds: seconds,
+		}
+	}
+
+	sheet := newTimesheet("jane", from, end)
+	assert.Equal(t, "2020-03-02", sheet.From)
+	assert.Equal(t, "2020-03-04", sheet.To)
+	assert.Len(t, sheet.Days, 3)
+	assert.Equal(t, "Mon 03-02", sheet.Days[0].Label)
+
+	err := sheet.addIssue("ABC-1", "First", jiradata.Worklogs{
+		worklog(2, 9, "jane", 3600),
+		worklog(2, 14, "jane", 1800),
+		// other authors and days outside the range are left out
+		worklog(2, 10, "sam", 7200),
+		worklog(1, 23, "jane", 600),
+		worklog(5, 0, "jane", 600),
+		worklog(4, 23, "jane", 900),
+	}, "jane-id", "jane")
+	assert.NoError(t, err)
+	err = sheet.addIssue("ABC-2", "Second", jiradata.Worklogs{
+		worklog(3, 8, "jane", 7200),
+		worklog(2, 8, "jane", 1200),
+	}, "jane-id")
+	assert.NoError(t, err)
+	err = sheet.addIssue("ABC-3", "Nothing", jiradata.Worklogs{worklog(3, 8, "sam", 7200)}, "jane")
+	assert.NoError(t, err)
+	sheet.total()
+
+	issueDays := func(issue *timesheetIssue) []int {
+		seconds := []int{}
+		for _, day := range issue.Days {
+			seconds = append(seconds, day.Seconds)
+		}
+		return seconds
+	}
+	assert.Len(t, sheet.Issues, 2)
+	assert.Equal(t, []int{5400, 0, 900}, issueDays(sheet.Issues[0]))
+	assert.Equal(t, "1h 30m", sheet.Issues[0].Days[0].TimeSpent)
+	assert.Equal(t, "", sheet.Issues[0].Days[1].TimeSpent)
+	assert.Equal(t, "1h 45m", sheet.Issues[0].TimeSpent)
+	assert.Equal(t, []int{1200, 7200, 0}, issueDays(sheet.Issues[1]))
+
+	days := []string{}
+	for _, day := range sheet.Days {
+		days = append(days, day.TimeSpent)
+	}
+	assert.Equal(t, []string{"1h 50m", "2h", "15m"}, days)
+	assert.Equal(t, 14700, sheet.Seconds)
+	assert.Equal(t, "4h 5m", sheet.TimeSpent)
+
+	started := []string{}
+	for _, worklog := range sheet.Worklogs {
+		started = append(started, worklog.Issue+" "+worklog.Date+" "+worklog.TimeSpent)
+	}
+	assert.Equal(t, []string{
+		"ABC-2 2020-03-02 20m",
+		"ABC-1 2020-03-02 1h",
+		"ABC-1 2020-03-02 30m",
+		"ABC-2 2020-03-03 2h",
+		"ABC-1 2020-03-04 15m",
+	}, started)
+
+	err = sheet.addIssue("ABC-4", "Bad", jiradata.Worklogs{{Author: &jiradata.User{Name: "jane"}, Started: "today"}}, "jane")
+	assert.Error(t, err)
+}
@@ -0,0 +1,263 @@
+package jiracmd
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	"github.com/go-jira/jira/jiradata"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type TreeOptions struct {
+	jiracli.CommonOptions `yaml:",inline" json:",inline" figtree:",inline"`
+	ReportOptions         `yaml:",inline" json:",inline" figtree:",inline"`
+	Project               string `yaml:"project,omitempty" json:"project,omitempty"`
+	Issue                 string `yaml:"issue,omitempty" json:"issue,omitempty"`
+	OpenOnly              bool   `yaml:"open-only,omitempty" json:"open-only,omitempty"`
+}
+
+func CmdTreeRegistry() *jiracli.CommandRegistryEntry {
+	opts := TreeOptions{
+		CommonOptions: jiracli.CommonOptions{
+			Template: figtree.NewStringOption("tree"),
+		},
+	}
+	return &jiracli.CommandRegistryEntry{
+		"Prints the hierarchy of epic children and subtasks with rolled-up progress",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdTreeUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			opts.Issue = jiracli.FormatIssue(opts.Issue, opts.Project)
+			return CmdTree(o, globals, &opts)
+		},
+	}
+}
+
+func CmdTreeUsage(cmd *kingpin.CmdClause, opts *TreeOptions) error {
+	jiracli.TemplateUsage(cmd, &opts.CommonOptions)
+	jiracli.GJsonQueryUsage(cmd, &opts.CommonOptions)
+	reportUsage(cmd, &opts.ReportOptions)
+	cmd.Flag("open-only", "Hide issues that are done, progress still counts them").BoolVar(&opts.OpenOnly)
+	cmd.Arg("ISSUE", "issue id of the initiative, epic or story").Required().StringVar(&opts.Issue)
+	return nil
+}
+
+// treeNode is an issue in the hierarchy, the progress counts the issues
+// below it, or the issue itself when it has no children.
+type treeNode struct {
+	Key         string      `json:"key" yaml:"key"`
+	Summary     string      `json:"summary" yaml:"summary"`
+	IssueType   string      `json:"issuetype" yaml:"issuetype"`
+	Status      string      `json:"status" yaml:"status"`
+	Category    string      `json:"category" yaml:"category"`
+	Assignee    string      `json:"assignee" yaml:"assignee"`
+	Depth       int         `json:"depth" yaml:"depth"`
+	Prefix      string      `json:"prefix" yaml:"prefix"`
+	Done        int         `json:"done" yaml:"done"`
+	Total       int         `json:"total" yaml:"total"`
+	Points      float64     `json:"points" yaml:"points"`
+	PointsDone  float64     `json:"pointsDone" yaml:"pointsDone"`
+	PointsTotal float64     `json:"pointsTotal" yaml:"pointsTotal"`
+	Percent     int         `json:"percent" yaml:"percent"`
+	Children    []*treeNode `json:"-" yaml:"-"`
+	level       int
+}
+
+type issueTree struct {
+	Issues []*treeNode `json:"issues" yaml:"issues"`
+}
+
+// CmdTree will fetch the issue and its descendants, roll up the progress of
+// each level and send the flattened tree to the "tree" template.
+func CmdTree(o *oreo.Client, globals *jiracli.GlobalOptions, opts *TreeOptions) error {
+	fields := []string{"summary", "status", "issuetype", "assignee", "subtasks"}
+	points, err := opts.findPointsField(o, globals.Endpoint.Value)
+	if err != nil {
+		// story points are optional unless a field was requested
+		if opts.PointsField != "" {
+			return err
+		}
+		points = nil
+	}
+	if points != nil {
+		fields = append(fields, points.ID)
+	}
+
+	issue, err := jira.GetIssue(o, globals.Endpoint.Value, opts.Issue, &jira.IssueOptions{
+		Fields: fields,
+	})
+	if err != nil {
+		return err
+	}
+	root, err := newTreeNode(issue, points)
+	if err != nil {
+		return err
+	}
+
+	seen := map[string]bool{root.Key: true}
+	var walk func(node *treeNode, issue *jiradata.Issue) error
+	walk = func(node *treeNode, issue *jiradata.Issue) error {
+		children, err := treeChildren(o, globals.Endpoint.Value, node, issue, fields)
+		if err != nil {
+			return err
+		}
+		for _, child := range children {
+			if seen[child.Key] {
+				continue
+			}
+			seen[child.Key] = true
+			childNode, err := newTreeNode(child, points)
+			if err != nil {
+				return err
+			}
+			childNode.Depth = node.Depth + 1
+			node.Children = append(node.Children, childNode)
+			if err := walk(childNode, child); err != nil {
+				return err
+			}
+		}
+		node.rollup()
+		return nil
+	}
+	if err := walk(root, issue); err != nil {
+		return err
+	}
+
+	tree := &issueTree{}
+	root.flatten(tree, "", "", opts.OpenOnly)
+	return opts.PrintTemplate(tree)
+}
+
+// treeChildren returns the children of the issue: the issues in an epic, the
+// subtasks of an issue, or the issues below a level above epics (ie
+// initiatives).
+func treeChildren(o *oreo.Client, endpoint string, node *treeNode, issue *jiradata.Issue, fields []string) (jiradata.Issues, error) {
+	query := &jira.SearchOptions{
+		Query:       fmt.Sprintf("parent = %s ORDER BY rank, key", issue.Key),
+		QueryFields: strings.Join(fields, ","),
+	}
+	switch {
+	case strings.EqualFold(node.IssueType, "epic") || node.level == 1:
+		query.Query = "ORDER BY rank, key"
+		results, err := jira.EpicSearch(o, endpoint, issue.Key, query, jira.WithAutoPagination())
+		if err != nil {
+			return nil, err
+		}
+		return results.Issues, nil
+	case node.level >= 2:
+		results, err := jira.Search(o, endpoint, query, jira.WithAutoPagination())
+		if err != nil {
+			return nil, err
+		}
+		return results.Issues, nil
+	}
+	subtasks := jiradata.Issues{}
+	if err := jiracli.ConvertType(issue.Fields["subtasks"], &subtasks); err != nil {
+		return nil, err
+	}
+	if len(subtasks) == 0 {
+		return nil, nil
+	}
+	// the subtasks field only has a few fields, so search for the rest
+	results, err := jira.Search(o, endpoint, query, jira.WithAutoPagination())
+	if err != nil {
+		return nil, err
+	}
+	return results.Issues, nil
+}
+
+func newTreeNode(issue *jiradata.Issue, points *jiradata.Field) (*treeNode, error) {
+	node := &treeNode{Key: issue.Key}
+	node.Summary, _ = issue.Fields["summary"].(string)
+	status := &jiradata.Status{}
+	if err := jiracli.ConvertType(issue.Fields["status"], status); err != nil {
+		return nil, err
+	}
+	node.Status = status.Name
+	if status.StatusCategory != nil {
+		node.Category = status.StatusCategory.Key
+	}
+	// hierarchyLevel is only reported by Jira Cloud, -1 for subtasks, 0 for
+	// standard issues, 1 for epics and above for levels like initiatives
+	issueType := struct {
+		Name           string `json:"name" yaml:"name"`
+		HierarchyLevel int    `json:"hierarchyLevel" yaml:"hierarchyLevel"`
+	}{}
+	if err := jiracli.ConvertType(issue.Fields["issuetype"], &issueType); err != nil {
+		return nil, err
+	}
+	node.IssueType = issueType.Name
+	node.level = issueType.HierarchyLevel
+	assignee := &jiradata.User{}
+	if err := jiracli.ConvertType(issue.Fields["assignee"], assignee); err != nil {
+		return nil, err
+	}
+	node.Assignee = assignee.DisplayName
+	if points != nil {
+		node.Points, _ = issue.Fields[points.ID].(float64)
+	}
+	return node, nil
+}
+
+// rollup computes the progress from the children, or from the issue itself
+// when it has no children.  Story points of an issue are only counted when
+// none of its children have story points.
+func (n *treeNode) rollup() {
+	n.Done, n.Total, n.PointsDone, n.PointsTotal = 0, 0, 0, 0
+	if len(n.Children) == 0 {
+		n.Total = 1
+		n.PointsTotal = n.Points
+		if n.Category == "done" {
+			n.Done = 1
+			n.PointsDone = n.Points
+		}
+	}
+	for _, child := range n.Children {
+		n.Done += child.Done
+		n.Total += child.Total
+		n.PointsDone += child.PointsDone
+		n.PointsTotal += child.PointsTotal
+	}
+	if len(n.Children) > 0 && n.PointsTotal == 0 && n.Points > 0 {
+		n.PointsTotal = n.Points
+		if n.Category == "done" {
+			n.PointsDone = n.Points
+		}
+	}
+	if n.PointsTotal > 0 {
+		n.Percent = int(math.Round(n.PointsDone / n.PointsTotal * 100))
+	} else if n.Total > 0 {
+		n.Percent = int(math.Round(float64(n.Done) / float64(n.Total) * 100))
+	}
+}
+
+// flatten appends the node and its children to the tree in display order,
+// prefix is the tree drawing for the node and indent the drawing for the
+// lines below it.
+func (n *treeNode) flatten(tree *issueTree, prefix, indent string, openOnly bool) {
+	if openOnly && n.Category == "done" && n.Depth > 0 {
+		return
+	}
+	n.Prefix = prefix
+	tree.Issues = append(tree.Issues, n)
+	children := []*treeNode{}
+	for _, child := range n.Children {
+		if !openOnly || child.Category != "done" {
+			children = append(children, child)
+		}
+	}
+	for i, child := range children {
+		if i == len(children)-1 {
+			child.flatten(tree, indent+"└── ", indent+"    ", openOnly)
+		} else {
+			child.flatten(tree, indent+"├── ", indent+"│   ", openOnly)
+		}
+	}
+}
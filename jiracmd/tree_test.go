@@ -0,0 +1,115 @@
+package jiracmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testTree builds the hierarchy:
+//
+//	EPIC-1
+//	├── A-1 done, 3 points
+//	├── A-2 5 points
+//	│   ├── A-3 done
+//	│   └── A-4
+//	└── A-5 done, 2 points
+func testTree() *treeNode {
+	node := func(key, category string, points float64, depth int, children ...*treeNode) *treeNode {
+		return &treeNode{Key: key, Category: category, Points: points, Depth: depth, Children: children}
+	}
+	return node("EPIC-1", "indeterminate", 0, 0,
+		node("A-1", "done", 3, 1),
+		node("A-2", "indeterminate", 5, 1,
+			node("A-3", "done", 0, 2),
+			node("A-4", "new", 0, 2),
+		),
+		node("A-5", "done", 2, 1),
+	)
+}
+
+// rollupTree rolls up the children before their parent, like CmdTree
+func rollupTree(n *treeNode) {
+	for _, child := range n.Children {
+		rollupTree(child)
+	}
+	n.rollup()
+}
+
+func TestTreeRollup(t *testing.T) {
+	root := testTree()
+	rollupTree(root)
+
+	leaf := root.Children[0]
+	assert.Equal(t, []int{1, 1, 100}, []int{leaf.Done, leaf.Total, leaf.Percent})
+	assert.Equal(t, []float64{3, 3}, []float64{leaf.PointsDone, leaf.PointsTotal})
+
+	// the subtasks have no points, so the points of the story are used
+	story := root.Children[1]
+	assert.Equal(t, []int{1, 2, 0}, []int{story.Done, story.Total, story.Percent})
+	assert.Equal(t, []float64{0, 5}, []float64{story.PointsDone, story.PointsTotal})
+
+	assert.Equal(t, []int{3, 4, 50}, []int{root.Done, root.Total, root.Percent})
+	assert.Equal(t, []float64{5, 10}, []float64{root.PointsDone, root.PointsTotal})
+}
+
+func TestTreeRollupCount(t *testing.T) {
+	root := &treeNode{Key: "EPIC-1", Children: []*treeNode{
+		{Key: "A-1", Category: "done"},
+		{Key: "A-2", Category: "indeterminate"},
+		{Key: "A-3", Category: "new"},
+	}}
+	rollupTree(root)
+	assert.Equal(t, []int{1, 3, 33}, []int{root.Done, root.Total, root.Percent})
+	assert.Equal(t, 0.0, root.PointsTotal)
+}
+
+func TestTreeRollupParentPointsDone(t *testing.T) {
+	root := &treeNode{Key: "A-1", Category: "done", Points: 8, Children: []*treeNode{
+		{Key: "A-2", Category: "done"},
+		{Key: "A-3", Category: "done"},
+	}}
+	rollupTree(root)
+	assert.Equal(t, []float64{8, 8}, []float64{root.PointsDone, root.PointsTotal})
+	assert.Equal(t, 100, root.Percent)
+
+	// points of the children take precedence over the parent points
+	root.Children[0].Points = 1
+	rollupTree(root)
+	assert.Equal(t, []float64{1, 1}, []float64{root.PointsDone, root.PointsTotal})
+}
+
+func TestTreeFlatten(t *testing.T) {
+	flatten := func(openOnly bool) [][]string {
+		tree := &issueTree{}
+		testTree().flatten(tree, "", "", openOnly)
+		lines := [][]string{}
+		for _, node := range tree.Issues {
+			lines = append(lines, []string{node.Prefix, node.Key})
+		}
+		return lines
+	}
+	assert.Equal(t, [][]string{
+		{"", "EPIC-1"},
+		{"├── ", "A-1"},
+		{"├── ", "A-2"},
+		{"│   ├── ", "A-3"},
+		{"│   └── ", "A-4"},
+		{"└── ", "A-5"},
+	}, flatten(false))
+	// the last open child is drawn as the last one
+	assert.Equal(t, [][]string{
+		{"", "EPIC-1"},
+		{"└── ", "A-2"},
+		{"    └── ", "A-4"},
+	}, flatten(true))
+}
+
+func TestTreeFlattenDoneRoot(t *testing.T) {
+	root := &treeNode{Key: "A-1", Category: "done", Children: []*treeNode{
+		{Key: "A-2", Category: "done", Depth: 1},
+	}}
+	tree := &issueTree{}
+	root.flatten(tree, "", "", true)
+	assert.Equal(t, []*treeNode{root}, tree.Issues)
+}
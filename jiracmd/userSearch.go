@@ -0,0 +1,64 @@
+package jiracmd
+
+import (
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type UserSearchOptions struct {
+	jiracli.CommonOptions `yaml:",inline" json:",inline" figtree:",inline"`
+	Query                 string `yaml:"query,omitempty" json:"query,omitempty"`
+	MaxResults            int    `yaml:"max-results,omitempty" json:"max-results,omitempty"`
+}
+
+func CmdUserSearchRegistry() *jiracli.CommandRegistryEntry {
+	opts := UserSearchOptions{
+		CommonOptions: jiracli.CommonOptions{
+			Template: figtree.NewStringOption("users"),
+		},
+	}
+
+	return &jiracli.CommandRegistryEntry{
+		"Search for users by name or email address",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdUserSearchUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			return CmdUserSearch(o, globals, &opts)
+		},
+	}
+}
+
+func CmdUserSearchUsage(cmd *kingpin.CmdClause, opts *UserSearchOptions) error {
+	jiracli.TemplateUsage(cmd, &opts.CommonOptions)
+	jiracli.GJsonQueryUsage(cmd, &opts.CommonOptions)
+	cmd.Flag("limit", "Maximum number of users to return").Short('l').IntVar(&opts.MaxResults)
+	cmd.Arg("QUERY", "Name, display name or email address to search for").Required().StringVar(&opts.Query)
+	return nil
+}
+
+// CmdUserSearch prints the users matching the query.
+func CmdUserSearch(o *oreo.Client, globals *jiracli.GlobalOptions, opts *UserSearchOptions) error {
+	cloud, err := isCloud(o, globals)
+	if err != nil {
+		return err
+	}
+	search := &jira.UserSearchOptions{
+		Query:      opts.Query,
+		MaxResults: opts.MaxResults,
+	}
+	if !cloud {
+		// Jira Server matches the username parameter against names, display
+		// names and email addresses
+		search.Username, search.Query = opts.Query, ""
+	}
+	users, err := jira.UserSearch(o, globals.Endpoint.Value, search)
+	if err != nil {
+		return err
+	}
+	return opts.PrintTemplate(users)
+}
@@ -0,0 +1,132 @@
+package jiracmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	"github.com/go-jira/jira/jiradata"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type UserShowOptions struct {
+	jiracli.CommonOptions `yaml:",inline" json:",inline" figtree:",inline"`
+	Account               string `yaml:"account,omitempty" json:"account,omitempty"`
+}
+
+func CmdUserShowRegistry() *jiracli.CommandRegistryEntry {
+	opts := UserShowOptions{
+		CommonOptions: jiracli.CommonOptions{
+			Template: figtree.NewStringOption("user"),
+		},
+	}
+
+	return &jiracli.CommandRegistryEntry{
+		"Prints the details and groups of a user",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdUserShowUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			return CmdUserShow(o, globals, &opts)
+		},
+	}
+}
+
+func CmdUserShowUsage(cmd *kingpin.CmdClause, opts *UserShowOptions) error {
+	jiracli.TemplateUsage(cmd, &opts.CommonOptions)
+	jiracli.GJsonQueryUsage(cmd, &opts.CommonOptions)
+	cmd.Arg("ACCOUNT", "Account id, username or email address of the user").Required().StringVar(&opts.Account)
+	return nil
+}
+
+// CmdUserShow prints the user along with the groups of the user.
+func CmdUserShow(o *oreo.Client, globals *jiracli.GlobalOptions, opts *UserShowOptions) error {
+	user, err := findUser(o, globals, opts.Account)
+	if err != nil {
+		return err
+	}
+	user, err = jira.GetUser(o, globals.Endpoint.Value, &jira.UserOptions{
+		AccountID: user.AccountID,
+		Username:  user.Name,
+		Expand:    "groups,applicationRoles",
+	})
+	if err != nil {
+		return err
+	}
+	return opts.PrintTemplate(user)
+}
+
+func isCloud(o *oreo.Client, globals *jiracli.GlobalOptions) (bool, error) {
+	if globals.JiraDeploymentType.Value == "" {
+		serverInfo, err := jira.ServerInfo(o, globals.Endpoint.Value)
+		if err != nil {
+			return false, err
+		}
+		globals.JiraDeploymentType.Value = strings.ToLower(serverInfo.DeploymentType)
+	}
+	return globals.JiraDeploymentType.Value == jiracli.CloudDeploymentType, nil
+}
+
+// findUser returns the user with the account id, username, email address or
+// display name.  It is an error when more than one user matches.
+func findUser(o *oreo.Client, globals *jiracli.GlobalOptions, account string) (*jiradata.User, error) {
+	cloud, err := isCloud(o, globals)
+	if err != nil {
+		return nil, err
+	}
+	search := &jira.UserSearchOptions{Username: account}
+	if cloud {
+		// account ids are not matched by the user search
+		if !strings.ContainsAny(account, "@ ") {
+			if user, err := jira.GetUser(o, globals.Endpoint.Value, &jira.UserOptions{AccountID: account}); err == nil {
+				return user, nil
+			}
+		}
+		search = &jira.UserSearchOptions{Query: account}
+	}
+	users, err := jira.UserSearch(o, globals.Endpoint.Value, search)
+	if err != nil {
+		return nil, err
+	}
+	matches := []*jiradata.User{}
+	for _, user := range users {
+		for _, value := range []string{user.AccountID, user.Name, user.EmailAddress, user.DisplayName} {
+			if value != "" && strings.EqualFold(value, account) {
+				matches = append(matches, user)
+				break
+			}
+		}
+	}
+	switch len(matches) {
+	case 0:
+		if len(users) == 0 {
+			return nil, fmt.Errorf("No user found for %q", account)
+		}
+		// the search also returns users only matching part of the account,
+		// list them rather than guessing
+		candidates := []string{}
+		for _, user := range users {
+			candidates = append(candidates, userCandidate(user))
+		}
+		return nil, fmt.Errorf("No user found for %q, similar users: %s", account, strings.Join(candidates, ", "))
+	case 1:
+		return matches[0], nil
+	}
+	return nil, fmt.Errorf("Found %d users for %q, use the account id or email address", len(matches), account)
+}
+
+// userCandidate describes a user by display name and account id or name.
+func userCandidate(user *jiradata.User) string {
+	id := user.AccountID
+	if id == "" {
+		id = user.Name
+	}
+	if user.DisplayName == "" {
+		return id
+	}
+	return fmt.Sprintf("%s (%s)", user.DisplayName, id)
+}
@@ -11,11 +11,21 @@ import (
 	kingpin "gopkg.in/alecthomas/kingpin.v2"
 )
 
+// WorklogEstimateOptions control how the remaining estimate of an issue is
+// adjusted when a worklog is added, updated or deleted.
+type WorklogEstimateOptions struct {
+	AdjustEstimate string `yaml:"adjust-estimate,omitempty" json:"adjust-estimate,omitempty"`
+	NewEstimate    string `yaml:"new-estimate,omitempty" json:"new-estimate,omitempty"`
+	ReduceBy       string `yaml:"reduce-by,omitempty" json:"reduce-by,omitempty"`
+	IncreaseBy     string `yaml:"increase-by,omitempty" json:"increase-by,omitempty"`
+}
+
 type WorklogAddOptions struct {
-	jiracli.CommonOptions `yaml:",inline" json:",inline" figtree:",inline"`
-	jiradata.Worklog      `yaml:",inline" json:",inline" figtree:",inline"`
-	Project               string `yaml:"project,omitempty" json:"project,omitempty"`
-	Issue                 string `yaml:"issue,omitempty" json:"issue,omitempty"`
+	jiracli.CommonOptions  `yaml:",inline" json:",inline" figtree:",inline"`
+	jiradata.Worklog       `yaml:",inline" json:",inline" figtree:",inline"`
+	WorklogEstimateOptions `yaml:",inline" json:",inline" figtree:",inline"`
+	Project                string `yaml:"project,omitempty" json:"project,omitempty"`
+	Issue                  string `yaml:"issue,omitempty" json:"issue,omitempty"`
 }
 
 func CmdWorklogAddRegistry() *jiracli.CommandRegistryEntry {
@@ -45,6 +55,9 @@ func CmdWorklogAddUsage(cmd *kingpin.CmdClause, opts *WorklogAddOptions) error {
 	cmd.Flag("comment", "Comment message for worklog").Short('m').StringVar(&opts.Comment)
 	cmd.Flag("time-spent", "Time spent working on issue").Short('T').StringVar(&opts.TimeSpent)
 	cmd.Flag("started", "Time you started work").Short('S').StringVar(&opts.Started)
+	cmd.Flag("adjust-estimate", "How to adjust the remaining estimate: auto, leave, new or manual").EnumVar(&opts.AdjustEstimate, "auto", "leave", "new", "manual")
+	cmd.Flag("new-estimate", "Remaining estimate when using --adjust-estimate=new (ie: 2d)").StringVar(&opts.NewEstimate)
+	cmd.Flag("reduce-by", "Amount to reduce remaining estimate when using --adjust-estimate=manual (ie: 3h)").StringVar(&opts.ReduceBy)
 	cmd.Arg("ISSUE", "issue id to fetch worklogs").Required().StringVar(&opts.Issue)
 	return nil
 }
@@ -53,8 +66,12 @@ func CmdWorklogAddUsage(cmd *kingpin.CmdClause, opts *WorklogAddOptions) error {
 // It will spawn the editor (unless --noedit isused) and post edited YAML
 // content as JSON to the worklog endpoint
 func CmdWorklogAdd(o *oreo.Client, globals *jiracli.GlobalOptions, opts *WorklogAddOptions) error {
-	err := jiracli.EditLoop(&opts.CommonOptions, &opts.Worklog, &opts.Worklog, func() error {
-		_, err := jira.AddIssueWorklog(o, globals.Endpoint.Value, opts.Issue, opts)
+	estimateOpts, err := opts.worklogOpts("reduce-by")
+	if err != nil {
+		return err
+	}
+	err = jiracli.EditLoop(&opts.CommonOptions, &opts.Worklog, &opts.Worklog, func() error {
+		_, err := jira.AddIssueWorklog(o, globals.Endpoint.Value, opts.Issue, opts, estimateOpts...)
 		return err
 	})
 	if err != nil {
@@ -68,3 +85,41 @@ func CmdWorklogAdd(o *oreo.Client, globals *jiracli.GlobalOptions, opts *Worklog
 	}
 	return nil
 }
+
+// worklogOpts validates the estimate options and converts them to the query
+// parameters for the worklog API.  manualFlag is the name of the flag that
+// must accompany the "manual" method, or empty if "manual" is not supported.
+func (o *WorklogEstimateOptions) worklogOpts(manualFlag string) ([]jira.WorklogOpt, error) {
+	opts := []jira.WorklogOpt{}
+	switch o.AdjustEstimate {
+	case "":
+		if o.NewEstimate != "" || o.ReduceBy != "" || o.IncreaseBy != "" {
+			return nil, fmt.Errorf("--adjust-estimate is required when changing the remaining estimate")
+		}
+		return opts, nil
+	case "auto", "leave":
+	case "new":
+		if o.NewEstimate == "" {
+			return nil, fmt.Errorf("--new-estimate is required with --adjust-estimate=new")
+		}
+		opts = append(opts, jira.WithNewEstimate(o.NewEstimate))
+	case "manual":
+		switch manualFlag {
+		case "reduce-by":
+			if o.ReduceBy == "" {
+				return nil, fmt.Errorf("--reduce-by is required with --adjust-estimate=manual")
+			}
+			opts = append(opts, jira.WithReduceBy(o.ReduceBy))
+		case "increase-by":
+			if o.IncreaseBy == "" {
+				return nil, fmt.Errorf("--increase-by is required with --adjust-estimate=manual")
+			}
+			opts = append(opts, jira.WithIncreaseBy(o.IncreaseBy))
+		default:
+			return nil, fmt.Errorf("--adjust-estimate=manual is not supported for this operation")
+		}
+	default:
+		return nil, fmt.Errorf("Invalid --adjust-estimate %q, expected auto, leave, new or manual", o.AdjustEstimate)
+	}
+	return append(opts, jira.WithAdjustEstimate(o.AdjustEstimate)), nil
+}
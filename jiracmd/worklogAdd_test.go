@@ -0,0 +1,65 @@
+package jiracmd
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiradata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorklogEstimateOptions(t *testing.T) {
+	// the options are only inspected through the query string they add to
+	// the worklog request
+	query := ""
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, "{}")
+	}))
+	defer server.Close()
+
+	for _, test := range []struct {
+		name       string
+		opts       WorklogEstimateOptions
+		manualFlag string
+		query      string
+		err        string
+	}{
+		{"none", WorklogEstimateOptions{}, "reduce-by", "", ""},
+		{"auto", WorklogEstimateOptions{AdjustEstimate: "auto"}, "reduce-by", "adjustEstimate=auto", ""},
+		{"leave", WorklogEstimateOptions{AdjustEstimate: "leave"}, "", "adjustEstimate=leave", ""},
+		{"new", WorklogEstimateOptions{AdjustEstimate: "new", NewEstimate: "2d"}, "reduce-by", "adjustEstimate=new&newEstimate=2d", ""},
+		{"reduce by", WorklogEstimateOptions{AdjustEstimate: "manual", ReduceBy: "3h"}, "reduce-by", "adjustEstimate=manual&reduceBy=3h", ""},
+		{"increase by", WorklogEstimateOptions{AdjustEstimate: "manual", IncreaseBy: "1h 30m"}, "increase-by", "adjustEstimate=manual&increaseBy=1h+30m", ""},
+		{"value without method", WorklogEstimateOptions{NewEstimate: "2d"}, "reduce-by", "",
+			"--adjust-estimate is required when changing the remaining estimate"},
+		{"reduce by without method", WorklogEstimateOptions{ReduceBy: "3h"}, "reduce-by", "",
+			"--adjust-estimate is required when changing the remaining estimate"},
+		{"new without estimate", WorklogEstimateOptions{AdjustEstimate: "new"}, "reduce-by", "",
+			"--new-estimate is required with --adjust-estimate=new"},
+		{"manual without reduce by", WorklogEstimateOptions{AdjustEstimate: "manual", IncreaseBy: "1h"}, "reduce-by", "",
+			"--reduce-by is required with --adjust-estimate=manual"},
+		{"manual without increase by", WorklogEstimateOptions{AdjustEstimate: "manual", ReduceBy: "1h"}, "increase-by", "",
+			"--increase-by is required with --adjust-estimate=manual"},
+		{"manual not supported", WorklogEstimateOptions{AdjustEstimate: "manual", ReduceBy: "1h"}, "", "",
+			"--adjust-estimate=manual is not supported for this operation"},
+		{"unknown method", WorklogEstimateOptions{AdjustEstimate: "later"}, "reduce-by", "",
+			`Invalid --adjust-estimate "later", expected auto, leave, new or manual`},
+	} {
+		opts, err := test.opts.worklogOpts(test.manualFlag)
+		if test.err != "" {
+			assert.EqualError(t, err, test.err, test.name)
+			continue
+		}
+		assert.NoError(t, err, test.name)
+		query = "unset"
+		_, err = jira.AddIssueWorklog(oreo.New(), server.URL, "ABC-1", &jiradata.Worklog{}, opts...)
+		assert.NoError(t, err, test.name)
+		assert.Equal(t, test.query, query, test.name)
+	}
+}
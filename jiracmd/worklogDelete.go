@@ -0,0 +1,59 @@
+package jiracmd
+
+import (
+	"fmt"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type WorklogDeleteOptions struct {
+	WorklogEstimateOptions `yaml:",inline" json:",inline" figtree:",inline"`
+	Project                string `yaml:"project,omitempty" json:"project,omitempty"`
+	Issue                  string `yaml:"issue,omitempty" json:"issue,omitempty"`
+	WorklogID              string `yaml:"worklog-id,omitempty" json:"worklog-id,omitempty"`
+}
+
+func CmdWorklogDeleteRegistry() *jiracli.CommandRegistryEntry {
+	opts := WorklogDeleteOptions{}
+
+	return &jiracli.CommandRegistryEntry{
+		"Delete a worklog from an issue",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdWorklogDeleteUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			opts.Issue = jiracli.FormatIssue(opts.Issue, opts.Project)
+			return CmdWorklogDelete(o, globals, &opts)
+		},
+	}
+}
+
+func CmdWorklogDeleteUsage(cmd *kingpin.CmdClause, opts *WorklogDeleteOptions) error {
+	cmd.Flag("adjust-estimate", "How to adjust the remaining estimate: auto, leave, new or manual").EnumVar(&opts.AdjustEstimate, "auto", "leave", "new", "manual")
+	cmd.Flag("new-estimate", "Remaining estimate when using --adjust-estimate=new (ie: 2d)").StringVar(&opts.NewEstimate)
+	cmd.Flag("increase-by", "Amount to increase remaining estimate when using --adjust-estimate=manual (ie: 3h)").StringVar(&opts.IncreaseBy)
+	cmd.Arg("ISSUE", "issue id of worklog").Required().StringVar(&opts.Issue)
+	cmd.Arg("WORKLOG-ID", "id of worklog to delete").Required().StringVar(&opts.WorklogID)
+	return nil
+}
+
+// CmdWorklogDelete will remove the worklog from the issue
+func CmdWorklogDelete(o *oreo.Client, globals *jiracli.GlobalOptions, opts *WorklogDeleteOptions) error {
+	estimateOpts, err := opts.worklogOpts("increase-by")
+	if err != nil {
+		return err
+	}
+	if err := jira.RemoveIssueWorklog(o, globals.Endpoint.Value, opts.Issue, opts.WorklogID, estimateOpts...); err != nil {
+		return err
+	}
+
+	if !globals.Quiet.Value {
+		fmt.Printf("OK Deleted Worklog %s from %s\n", opts.WorklogID, opts.Issue)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package jiracmd
+
+import (
+	"fmt"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	"github.com/go-jira/jira/jiradata"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+type WorklogEditOptions struct {
+	jiracli.CommonOptions  `yaml:",inline" json:",inline" figtree:",inline"`
+	jiradata.Worklog       `yaml:",inline" json:",inline" figtree:",inline"`
+	WorklogEstimateOptions `yaml:",inline" json:",inline" figtree:",inline"`
+	Project                string `yaml:"project,omitempty" json:"project,omitempty"`
+	Issue                  string `yaml:"issue,omitempty" json:"issue,omitempty"`
+	WorklogID              string `yaml:"worklog-id,omitempty" json:"worklog-id,omitempty"`
+}
+
+func CmdWorklogEditRegistry() *jiracli.CommandRegistryEntry {
+	opts := WorklogEditOptions{
+		CommonOptions: jiracli.CommonOptions{
+			Template: figtree.NewStringOption("worklog"),
+		},
+	}
+	return &jiracli.CommandRegistryEntry{
+		"Edit a worklog on an issue",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdWorklogEditUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			opts.Issue = jiracli.FormatIssue(opts.Issue, opts.Project)
+			return CmdWorklogEdit(o, globals, &opts)
+		},
+	}
+}
+
+func CmdWorklogEditUsage(cmd *kingpin.CmdClause, opts *WorklogEditOptions) error {
+	jiracli.BrowseUsage(cmd, &opts.CommonOptions)
+	jiracli.EditorUsage(cmd, &opts.CommonOptions)
+	jiracli.TemplateUsage(cmd, &opts.CommonOptions)
+	cmd.Flag("noedit", "Disable opening the editor").SetValue(&opts.SkipEditing)
+	cmd.Flag("comment", "Comment message for worklog").Short('m').StringVar(&opts.Comment)
+	cmd.Flag("time-spent", "Time spent working on issue").Short('T').StringVar(&opts.TimeSpent)
+	cmd.Flag("started", "Time you started work").Short('S').StringVar(&opts.Started)
+	cmd.Flag("adjust-estimate", "How to adjust the remaining estimate: auto, leave or new").EnumVar(&opts.AdjustEstimate, "auto", "leave", "new")
+	cmd.Flag("new-estimate", "Remaining estimate when using --adjust-estimate=new (ie: 2d)").StringVar(&opts.NewEstimate)
+	cmd.Arg("ISSUE", "issue id of worklog").Required().StringVar(&opts.Issue)
+	cmd.Arg("WORKLOG-ID", "id of worklog to edit").Required().StringVar(&opts.WorklogID)
+	return nil
+}
+
+// CmdWorklogEdit will send the existing worklog, updated with any values from
+// the command line, to the "worklog" template for editing and then submit the
+// edited worklog.
+func CmdWorklogEdit(o *oreo.Client, globals *jiracli.GlobalOptions, opts *WorklogEditOptions) error {
+	estimateOpts, err := opts.worklogOpts("")
+	if err != nil {
+		return err
+	}
+	existing, err := jira.GetIssueWorklogByID(o, globals.Endpoint.Value, opts.Issue, opts.WorklogID)
+	if err != nil {
+		return err
+	}
+	if opts.Comment != "" {
+		existing.Comment = opts.Comment
+	}
+	if opts.TimeSpent != "" {
+		existing.TimeSpent = opts.TimeSpent
+	}
+	if opts.Started != "" {
+		existing.Started = opts.Started
+	}
+
+	worklog := jiradata.Worklog{}
+	err = jiracli.EditLoop(&opts.CommonOptions, existing, &worklog, func() error {
+		worklog.Visibility = existing.Visibility
+		_, err := jira.EditIssueWorklog(o, globals.Endpoint.Value, opts.Issue, opts.WorklogID, &worklog, estimateOpts...)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if !globals.Quiet.Value {
+		fmt.Printf("OK %s %s\n", opts.Issue, jira.URLJoin(globals.Endpoint.Value, "browse", opts.Issue))
+	}
+	if opts.Browse.Value {
+		return CmdBrowse(globals, opts.Issue)
+	}
+	return nil
+}
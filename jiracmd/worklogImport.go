@@ -0,0 +1,510 @@
+package jiracmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/coryb/figtree"
+	"github.com/coryb/oreo"
+	"github.com/go-jira/jira"
+	"github.com/go-jira/jira/jiracli"
+	"github.com/go-jira/jira/jiradata"
+	"golang.org/x/crypto/ssh/terminal"
+	"gopkg.in/AlecAivazis/survey.v1"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+const defaultIssuePattern = `[A-Z][A-Z0-9_]+-[0-9]+`
+
+type WorklogImportOptions struct {
+	jiracli.CommonOptions `yaml:",inline" json:",inline" figtree:",inline"`
+	Project               string `yaml:"project,omitempty" json:"project,omitempty"`
+	ImportFile            string `yaml:"import-file,omitempty" json:"import-file,omitempty"`
+	Format                string `yaml:"format,omitempty" json:"format,omitempty"`
+	IssueColumn           string `yaml:"issue-column,omitempty" json:"issue-column,omitempty"`
+	IssuePattern          string `yaml:"issue-pattern,omitempty" json:"issue-pattern,omitempty"`
+	DryRun                bool   `yaml:"dryrun,omitempty" json:"dryrun,omitempty"`
+	Yes                   bool   `yaml:"yes,omitempty" json:"yes,omitempty"`
+}
+
+func CmdWorklogImportRegistry() *jiracli.CommandRegistryEntry {
+	opts := WorklogImportOptions{
+		CommonOptions: jiracli.CommonOptions{
+			Template: figtree.NewStringOption("worklog-import"),
+		},
+	}
+	return &jiracli.CommandRegistryEntry{
+		"Import worklogs from CSV, timewarrior or Toggl exports",
+		func(fig *figtree.FigTree, cmd *kingpin.CmdClause) error {
+			jiracli.LoadConfigs(cmd, fig, &opts)
+			return CmdWorklogImportUsage(cmd, &opts)
+		},
+		func(o *oreo.Client, globals *jiracli.GlobalOptions) error {
+			if opts.IssueColumn == "" {
+				opts.IssueColumn = "issue"
+			}
+			if opts.IssuePattern == "" {
+				opts.IssuePattern = defaultIssuePattern
+			}
+			return CmdWorklogImport(o, globals, &opts)
+		},
+	}
+}
+
+func CmdWorklogImportUsage(cmd *kingpin.CmdClause, opts *WorklogImportOptions) error {
+	jiracli.TemplateUsage(cmd, &opts.CommonOptions)
+	cmd.Flag("format", "Format of FILE: csv, timewarrior or toggl, detected from the content by default, CSV durations can be seconds, 1d 2h 30m (8 hour days, 5 day weeks) or HH:MM[:SS]").EnumVar(&opts.Format, "csv", "timewarrior", "toggl")
+	cmd.Flag("issue-column", "CSV column containing the issue key, defaults to \"issue\"").StringVar(&opts.IssueColumn)
+	cmd.Flag("issue-pattern", "Regexp used to find the issue key in descriptions and tags when there is no issue column").StringVar(&opts.IssuePattern)
+	cmd.Flag("project", "Project used for issue keys that are only a number").Short('p').StringVar(&opts.Project)
+	cmd.Flag("dryrun", "Only print the worklogs that would be imported").BoolVar(&opts.DryRun)
+	cmd.Flag("yes", "Import without asking for confirmation").BoolVar(&opts.Yes)
+	cmd.Arg("FILE", "export file to import, or - for stdin").Required().StringVar(&opts.ImportFile)
+	return nil
+}
+
+// worklogImportEntry is a single row of the import file along with the status
+// of the worklog after comparing it to the existing worklogs of the issue.
+type worklogImportEntry struct {
+	Line             int    `json:"line" yaml:"line"`
+	Issue            string `json:"issue" yaml:"issue"`
+	Started          string `json:"started" yaml:"started"`
+	TimeSpentSeconds int    `json:"timeSpentSeconds" yaml:"timeSpentSeconds"`
+	TimeSpent        string `json:"timeSpent" yaml:"timeSpent"`
+	Comment          string `json:"comment" yaml:"comment"`
+	Status           string `json:"status" yaml:"status"`
+	started          time.Time
+}
+
+const (
+	worklogImportNew       = "new"
+	worklogImportDuplicate = "duplicate"
+)
+
+// CmdWorklogImport will parse the worklogs from the import file, print a
+// preview using the "worklog-import" template and then add the worklogs that
+// do not already exist on the issues.
+func CmdWorklogImport(o *oreo.Client, globals *jiracli.GlobalOptions, opts *WorklogImportOptions) error {
+	var data []byte
+	var err error
+	if opts.ImportFile == "-" {
+		data, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		data, err = ioutil.ReadFile(opts.ImportFile)
+	}
+	if err != nil {
+		return err
+	}
+
+	issuePattern, err := regexp.Compile(opts.IssuePattern)
+	if err != nil {
+		return fmt.Errorf("Invalid --issue-pattern %q: %s", opts.IssuePattern, err)
+	}
+
+	format := opts.Format
+	if format == "" {
+		format = detectWorklogImportFormat(opts.ImportFile, data)
+	}
+
+	var entries []*worklogImportEntry
+	switch format {
+	case "timewarrior":
+		entries, err = parseTimewarriorExport(data, issuePattern)
+	case "toggl":
+		entries, err = parseTogglExport(data, opts.IssueColumn, issuePattern)
+	default:
+		entries, err = parseWorklogCSV(data, opts.IssueColumn, issuePattern)
+	}
+	if err != nil {
+		return err
+	}
+
+	existing := map[string][]*worklogImportEntry{}
+	pending := 0
+	for _, entry := range entries {
+		if entry.Status != "" {
+			continue
+		}
+		entry.Issue = jiracli.FormatIssue(entry.Issue, opts.Project)
+		if _, ok := existing[entry.Issue]; !ok {
+			worklogs, err := jira.GetIssueWorklog(o, globals.Endpoint.Value, entry.Issue)
+			if err != nil {
+				return err
+			}
+			existing[entry.Issue] = []*worklogImportEntry{}
+			for _, worklog := range *worklogs {
+				started, err := time.Parse(jiraTimeFormat, worklog.Started)
+				if err != nil {
+					return err
+				}
+				existing[entry.Issue] = append(existing[entry.Issue], &worklogImportEntry{
+					TimeSpentSeconds: worklog.TimeSpentSeconds,
+					started:          started,
+				})
+			}
+		}
+		entry.Status = worklogImportNew
+		for _, other := range existing[entry.Issue] {
+			if other.TimeSpentSeconds == entry.TimeSpentSeconds && other.started.Truncate(time.Minute).Equal(entry.started.Truncate(time.Minute)) {
+				entry.Status = worklogImportDuplicate
+				break
+			}
+		}
+		if entry.Status == worklogImportNew {
+			// also catch rows repeated within the import file
+			existing[entry.Issue] = append(existing[entry.Issue], entry)
+			pending++
+		}
+	}
+
+	if err := opts.PrintTemplate(struct {
+		Worklogs []*worklogImportEntry `json:"worklogs" yaml:"worklogs"`
+	}{entries}); err != nil {
+		return err
+	}
+
+	if opts.DryRun {
+		return nil
+	}
+	if pending == 0 {
+		if !globals.Quiet.Value {
+			fmt.Println("OK No new worklogs to import")
+		}
+		return nil
+	}
+	if !opts.Yes {
+		if !terminal.IsTerminal(int(os.Stdin.Fd())) || opts.ImportFile == "-" {
+			return fmt.Errorf("Refusing to import %d worklogs without confirmation, use --yes", pending)
+		}
+		answer := false
+		err := survey.AskOne(
+			&survey.Confirm{
+				Message: fmt.Sprintf("Import %d worklogs?", pending),
+				Default: false,
+			},
+			&answer,
+			nil,
+		)
+		if err != nil {
+			return err
+		}
+		if !answer {
+			panic(jiracli.Exit{1})
+		}
+	}
+
+	for _, entry := range entries {
+		if entry.Status != worklogImportNew {
+			continue
+		}
+		worklog := jiradata.Worklog{
+			Comment:          entry.Comment,
+			Started:          entry.Started,
+			TimeSpentSeconds: entry.TimeSpentSeconds,
+		}
+		if _, err := jira.AddIssueWorklog(o, globals.Endpoint.Value, entry.Issue, &worklog); err != nil {
+			return fmt.Errorf("Failed to import line %d: %s", entry.Line, err)
+		}
+		if !globals.Quiet.Value {
+			fmt.Printf("OK %s logged %s %s\n", entry.Issue, entry.TimeSpent, jira.URLJoin(globals.Endpoint.Value, "browse", entry.Issue))
+		}
+	}
+	return nil
+}
+
+func detectWorklogImportFormat(file string, data []byte) string {
+	trimmed := bytes.TrimSpace(data)
+	if strings.EqualFold(filepath.Ext(file), ".json") || bytes.HasPrefix(trimmed, []byte("[")) {
+		return "timewarrior"
+	}
+	firstLine := strings.ToLower(strings.SplitN(string(trimmed), "\n", 2)[0])
+	if strings.Contains(firstLine, "start date") && strings.Contains(firstLine, "duration") {
+		return "toggl"
+	}
+	return "csv"
+}
+
+// newWorklogImportEntry fills in the Jira formatted fields of the entry.  Jira
+// does not accept worklogs of less than a minute, so the time spent is
+// rounded up to whole minutes.  Entries without a positive time spent are
+// skipped, as they are likely an end before the start or a typo.
+func newWorklogImportEntry(line int, issue string, started time.Time, spent time.Duration, comment string) *worklogImportEntry {
+	if spent <= 0 {
+		return skippedWorklogImportEntry(line, issue, comment, fmt.Errorf("time spent %s is not positive", spent))
+	}
+	entry := &worklogImportEntry{
+		Line:    line,
+		Issue:   issue,
+		Comment: strings.TrimSpace(comment),
+		started: started,
+	}
+	minutes := int((spent + time.Minute - 1) / time.Minute)
+	entry.TimeSpentSeconds = minutes * 60
+	entry.TimeSpent = formatSeconds(entry.TimeSpentSeconds)
+	entry.Started = started.Format(jiraTimeFormat)
+	if issue == "" {
+		entry.Status = "skipped: no issue key"
+	}
+	return entry
+}
+
+func skippedWorklogImportEntry(line int, issue, comment string, err error) *worklogImportEntry {
+	return &worklogImportEntry{
+		Line:    line,
+		Issue:   issue,
+		Comment: strings.TrimSpace(comment),
+		Status:  fmt.Sprintf("skipped: %s", err),
+	}
+}
+
+// findIssueKey returns the issue key from the first of values matching the
+// issue pattern.
+func findIssueKey(pattern *regexp.Regexp, values ...string) string {
+	for _, value := range values {
+		if key := pattern.FindString(value); key != "" {
+			return key
+		}
+	}
+	return ""
+}
+
+// normalizeColumn makes CSV column names comparable, ie "Time Spent",
+// "time-spent" and "timeSpent" are all the same column.
+func normalizeColumn(name string) string {
+	return strings.ToLower(strings.NewReplacer(" ", "", "-", "", "_", "").Replace(strings.TrimSpace(name)))
+}
+
+// readImportCSV returns the rows of the CSV data as maps of normalized column
+// name to value.
+func readImportCSV(data []byte) ([]map[string]string, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("Import file is empty")
+	}
+	header := records[0]
+	rows := []map[string]string{}
+	for _, record := range records[1:] {
+		row := map[string]string{}
+		for i, value := range record {
+			if i < len(header) {
+				row[normalizeColumn(header[i])] = strings.TrimSpace(value)
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// firstColumn returns the value of the first column present in the row.
+func firstColumn(row map[string]string, columns ...string) string {
+	for _, column := range columns {
+		if value, ok := row[normalizeColumn(column)]; ok && value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+var importTimeLayouts = []string{
+	jiraTimeFormat,
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+}
+
+func parseImportTime(value string) (time.Time, error) {
+	for _, layout := range importTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q", value)
+}
+
+// jiraDurationPattern matches Jira style durations like "1w 2d 3h 30m".
+var (
+	jiraDurationPattern     = regexp.MustCompile(`^(?:\s*\d+(?:\.\d+)?\s*[wdhms])+\s*$`)
+	jiraDurationUnitPattern = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*([wdhms])`)
+	jiraDurationUnits       = map[string]time.Duration{
+		"w": 5 * 8 * time.Hour,
+		"d": 8 * time.Hour,
+		"h": time.Hour,
+		"m": time.Minute,
+		"s": time.Second,
+	}
+)
+
+// parseImportDuration accepts a plain number of seconds, Jira style durations
+// (ie "1d 2h 30m", using the default Jira time tracking of 8 hour days and 5
+// day weeks), Go durations and HH:MM[:SS].
+func parseImportDuration(value string) (time.Duration, error) {
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second, nil
+	}
+	if jiraDurationPattern.MatchString(value) {
+		d := time.Duration(0)
+		for _, m := range jiraDurationUnitPattern.FindAllStringSubmatch(value, -1) {
+			n, err := strconv.ParseFloat(m[1], 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid duration %q", value)
+			}
+			d += time.Duration(n * float64(jiraDurationUnits[m[2]]))
+		}
+		return d, nil
+	}
+	if d, err := time.ParseDuration(strings.Replace(value, " ", "", -1)); err == nil {
+		return d, nil
+	}
+	if parts := strings.Split(value, ":"); len(parts) == 2 || len(parts) == 3 {
+		d := time.Duration(0)
+		units := []time.Duration{time.Hour, time.Minute, time.Second}
+		for i, part := range parts {
+			n, err := strconv.Atoi(part)
+			if err != nil {
+				return 0, fmt.Errorf("invalid duration %q", value)
+			}
+			d += time.Duration(n) * units[i]
+		}
+		return d, nil
+	}
+	return 0, fmt.Errorf("invalid duration %q", value)
+}
+
+// parseWorklogCSV parses a CSV file with a header row.  The start time is read
+// from a "started" or "start" column, the time spent from a "time spent",
+// "seconds" or "duration" column or computed from an "end" column, and the
+// comment from a "comment" or "description" column.
+func parseWorklogCSV(data []byte, issueColumn string, issuePattern *regexp.Regexp) ([]*worklogImportEntry, error) {
+	rows, err := readImportCSV(data)
+	if err != nil {
+		return nil, err
+	}
+	entries := []*worklogImportEntry{}
+	for i, row := range rows {
+		line := i + 2
+		comment := firstColumn(row, "comment", "description")
+		issue := firstColumn(row, issueColumn)
+		if issue == "" {
+			issue = findIssueKey(issuePattern, comment)
+		}
+		started, err := parseImportTime(firstColumn(row, "started", "start"))
+		if err != nil {
+			entries = append(entries, skippedWorklogImportEntry(line, issue, comment, err))
+			continue
+		}
+		var spent time.Duration
+		if value := firstColumn(row, "time spent", "time spent seconds", "seconds", "duration"); value != "" {
+			spent, err = parseImportDuration(value)
+		} else {
+			var end time.Time
+			end, err = parseImportTime(firstColumn(row, "end", "stop"))
+			spent = end.Sub(started)
+		}
+		if err != nil {
+			entries = append(entries, skippedWorklogImportEntry(line, issue, comment, err))
+			continue
+		}
+		entries = append(entries, newWorklogImportEntry(line, issue, started, spent, comment))
+	}
+	return entries, nil
+}
+
+// parseTogglExport parses the Toggl "detailed" CSV report.
+func parseTogglExport(data []byte, issueColumn string, issuePattern *regexp.Regexp) ([]*worklogImportEntry, error) {
+	rows, err := readImportCSV(data)
+	if err != nil {
+		return nil, err
+	}
+	entries := []*worklogImportEntry{}
+	for i, row := range rows {
+		line := i + 2
+		comment := firstColumn(row, "description")
+		issue := firstColumn(row, issueColumn)
+		if issue == "" {
+			issue = findIssueKey(issuePattern, comment, firstColumn(row, "task"), firstColumn(row, "tags"), firstColumn(row, "project"))
+		}
+		started, err := parseImportTime(firstColumn(row, "start date") + " " + firstColumn(row, "start time"))
+		if err != nil {
+			entries = append(entries, skippedWorklogImportEntry(line, issue, comment, err))
+			continue
+		}
+		spent, err := parseImportDuration(firstColumn(row, "duration"))
+		if err != nil {
+			entries = append(entries, skippedWorklogImportEntry(line, issue, comment, err))
+			continue
+		}
+		entries = append(entries, newWorklogImportEntry(line, issue, started, spent, comment))
+	}
+	return entries, nil
+}
+
+// timewarriorInterval is an interval from the output of "timew export"
+type timewarriorInterval struct {
+	ID         int      `json:"id"`
+	Start      string   `json:"start"`
+	End        string   `json:"end"`
+	Tags       []string `json:"tags"`
+	Annotation string   `json:"annotation"`
+}
+
+const timewarriorTimeFormat = "20060102T150405Z"
+
+// parseTimewarriorExport parses the JSON output of "timew export".  The issue
+// key is found in the tags or annotation, and the annotation is used as the
+// comment, falling back to the remaining tags.
+func parseTimewarriorExport(data []byte, issuePattern *regexp.Regexp) ([]*worklogImportEntry, error) {
+	intervals := []timewarriorInterval{}
+	if err := json.Unmarshal(data, &intervals); err != nil {
+		return nil, err
+	}
+	entries := []*worklogImportEntry{}
+	for i, interval := range intervals {
+		line := interval.ID
+		if line == 0 {
+			line = i + 1
+		}
+		issue := findIssueKey(issuePattern, append(interval.Tags, interval.Annotation)...)
+		comment := interval.Annotation
+		if comment == "" {
+			tags := []string{}
+			for _, tag := range interval.Tags {
+				if !issuePattern.MatchString(tag) {
+					tags = append(tags, tag)
+				}
+			}
+			comment = strings.Join(tags, " ")
+		}
+		if interval.End == "" {
+			entries = append(entries, skippedWorklogImportEntry(line, issue, comment, fmt.Errorf("interval still open")))
+			continue
+		}
+		started, err := time.Parse(timewarriorTimeFormat, interval.Start)
+		if err != nil {
+			entries = append(entries, skippedWorklogImportEntry(line, issue, comment, err))
+			continue
+		}
+		end, err := time.Parse(timewarriorTimeFormat, interval.End)
+		if err != nil {
+			entries = append(entries, skippedWorklogImportEntry(line, issue, comment, err))
+			continue
+		}
+		entries = append(entries, newWorklogImportEntry(line, issue, started.Local(), end.Sub(started), comment))
+	}
+	return entries, nil
+}
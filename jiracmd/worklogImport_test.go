@@ -0,0 +1,149 @@
+package jiracmd
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectWorklogImportFormat(t *testing.T) {
+	for _, test := range []struct {
+		file   string
+		data   string
+		format string
+	}{
+		{"export.json", `{}`, "timewarrior"},
+		{"-", "  [{\"start\":\"20200101T090000Z\"}]", "timewarrior"},
+		{"report.csv", "User,Email,Client,Project,Task,Description,Billable,Start date,Start time,End date,End time,Duration\n", "toggl"},
+		{"log.csv", "issue,started,time spent\n", "csv"},
+		{"-", "", "csv"},
+	} {
+		assert.Equal(t, test.format, detectWorklogImportFormat(test.file, []byte(test.data)), test.file)
+	}
+}
+
+func TestParseImportDuration(t *testing.T) {
+	for _, test := range []struct {
+		value    string
+		duration time.Duration
+		err      bool
+	}{
+		{"90", 90 * time.Second, false},
+		{"1h 30m", 90 * time.Minute, false},
+		{"1h30m", 90 * time.Minute, false},
+		{"45m", 45 * time.Minute, false},
+		{"1d 2h", 10 * time.Hour, false},
+		{"1w", 40 * time.Hour, false},
+		{"1.5h", 90 * time.Minute, false},
+		{"2h 15m 30s", 2*time.Hour + 15*time.Minute + 30*time.Second, false},
+		{"1m30s", 90 * time.Second, false},
+		{"1:30", 90 * time.Minute, false},
+		{"01:02:03", time.Hour + 2*time.Minute + 3*time.Second, false},
+		{"-5", -5 * time.Second, false},
+		{"", 0, true},
+		{"soon", 0, true},
+		{"1:xx", 0, true},
+		{"1y", 0, true},
+	} {
+		d, err := parseImportDuration(test.value)
+		if test.err {
+			assert.Error(t, err, test.value)
+			continue
+		}
+		assert.NoError(t, err, test.value)
+		assert.Equal(t, test.duration, d, test.value)
+	}
+}
+
+func localJiraTime(year int, month time.Month, day, hour, min int) string {
+	return time.Date(year, month, day, hour, min, 0, 0, time.Local).Format(jiraTimeFormat)
+}
+
+func TestParseWorklogCSV(t *testing.T) {
+	entries, err := parseWorklogCSV([]byte("\xef\xbb\xbfIssue,Started,Time Spent,Comment\n"+
+		"ABC-1,2020-01-02 09:00,1h 30m,first\n"+
+		",2020-01-02 11:00,30,fixed XYZ-9 today\n"+
+		"ABC-2,yesterday,1h,bad time\n"+
+		"ABC-3,2020-01-02 13:00,-1h,negative\n"+
+		",2020-01-02 14:00,1h,no key\n"), "issue", regexp.MustCompile(defaultIssuePattern))
+	assert.NoError(t, err)
+	assert.Len(t, entries, 5)
+
+	assert.Equal(t, 2, entries[0].Line)
+	assert.Equal(t, "ABC-1", entries[0].Issue)
+	assert.Equal(t, localJiraTime(2020, 1, 2, 9, 0), entries[0].Started)
+	assert.Equal(t, 5400, entries[0].TimeSpentSeconds)
+	assert.Equal(t, "1h 30m", entries[0].TimeSpent)
+	assert.Equal(t, "first", entries[0].Comment)
+	assert.Equal(t, "", entries[0].Status)
+
+	// the issue key is found in the comment, seconds are rounded up to a minute
+	assert.Equal(t, "XYZ-9", entries[1].Issue)
+	assert.Equal(t, 60, entries[1].TimeSpentSeconds)
+
+	assert.Equal(t, `skipped: invalid time "yesterday"`, entries[2].Status)
+	assert.Equal(t, "skipped: time spent -1h0m0s is not positive", entries[3].Status)
+	assert.Equal(t, "skipped: no issue key", entries[4].Status)
+
+	// the time spent is computed from the end when there is no duration
+	entries, err = parseWorklogCSV([]byte("key,start,end,description\n"+
+		"ABC-1,2020-01-02T09:00,2020-01-02T09:45,standup\n"+
+		"ABC-1,2020-01-02T10:00,2020-01-02T09:00,backwards\n"), "key", regexp.MustCompile(defaultIssuePattern))
+	assert.NoError(t, err)
+	assert.Equal(t, 2700, entries[0].TimeSpentSeconds)
+	assert.Equal(t, "standup", entries[0].Comment)
+	assert.Equal(t, "skipped: time spent -1h0m0s is not positive", entries[1].Status)
+
+	_, err = parseWorklogCSV([]byte(""), "issue", regexp.MustCompile(defaultIssuePattern))
+	assert.EqualError(t, err, "Import file is empty")
+}
+
+func TestParseTogglExport(t *testing.T) {
+	entries, err := parseTogglExport([]byte("User,Email,Client,Project,Task,Description,Billable,Start date,Start time,End date,End time,Duration,Tags\n"+
+		"Jane,jane@example.com,,Work,,ABC-1 review,No,2020-01-02,09:00:00,2020-01-02,10:15:00,01:15:00,\n"+
+		"Jane,jane@example.com,,ABC-2,,meeting,No,2020-01-02,11:00:00,2020-01-02,11:30:00,00:30:00,\n"+
+		"Jane,jane@example.com,,Work,,tagged,No,2020-01-02,12:00:00,2020-01-02,12:30:00,00:30:00,XYZ-3\n"+
+		"Jane,jane@example.com,,Work,,broken,No,2020-01-02,13:00:00,2020-01-02,13:30:00,half an hour,\n"),
+		"issue", regexp.MustCompile(defaultIssuePattern))
+	assert.NoError(t, err)
+	assert.Len(t, entries, 4)
+
+	assert.Equal(t, "ABC-1", entries[0].Issue)
+	assert.Equal(t, localJiraTime(2020, 1, 2, 9, 0), entries[0].Started)
+	assert.Equal(t, "1h 15m", entries[0].TimeSpent)
+	assert.Equal(t, "ABC-1 review", entries[0].Comment)
+	// the key is also found in the project and tags
+	assert.Equal(t, "ABC-2", entries[1].Issue)
+	assert.Equal(t, "XYZ-3", entries[2].Issue)
+	assert.Equal(t, `skipped: invalid duration "half an hour"`, entries[3].Status)
+}
+
+func TestParseTimewarriorExport(t *testing.T) {
+	entries, err := parseTimewarriorExport([]byte(`[
+		{"id":3,"start":"20200102T090000Z","end":"20200102T093000Z","tags":["ABC-1","review"]},
+		{"id":2,"start":"20200102T100000Z","end":"20200102T110000Z","tags":["meeting"],"annotation":"planning XYZ-2"},
+		{"id":1,"start":"20200102T120000Z","tags":["ABC-1"]},
+		{"start":"bad","end":"20200102T130000Z","tags":["ABC-1"]}
+	]`), regexp.MustCompile(defaultIssuePattern))
+	assert.NoError(t, err)
+	assert.Len(t, entries, 4)
+
+	assert.Equal(t, 3, entries[0].Line)
+	assert.Equal(t, "ABC-1", entries[0].Issue)
+	assert.Equal(t, time.Date(2020, 1, 2, 9, 0, 0, 0, time.UTC).Local().Format(jiraTimeFormat), entries[0].Started)
+	assert.Equal(t, "30m", entries[0].TimeSpent)
+	// the comment falls back to the tags without the issue key
+	assert.Equal(t, "review", entries[0].Comment)
+
+	assert.Equal(t, "XYZ-2", entries[1].Issue)
+	assert.Equal(t, "planning XYZ-2", entries[1].Comment)
+	assert.Equal(t, "skipped: interval still open", entries[2].Status)
+	// intervals without an id are numbered by position
+	assert.Equal(t, 4, entries[3].Line)
+	assert.Contains(t, entries[3].Status, "skipped: parsing time")
+
+	_, err = parseTimewarriorExport([]byte(`{`), regexp.MustCompile(defaultIssuePattern))
+	assert.Error(t, err)
+}
@@ -0,0 +1,34 @@
+package jiradata
+
+// ADFNode is a node of an Atlassian Document Format document, as used by the
+// version 3 REST API for descriptions, comments and other rich text fields.
+// The root node of a document has the "doc" Type and Version 1.
+type ADFNode struct {
+	Type    string                 `json:"type" yaml:"type"`
+	Version int                    `json:"version,omitempty" yaml:"version,omitempty"`
+	Attrs   map[string]interface{} `json:"attrs,omitempty" yaml:"attrs,omitempty"`
+	Content []*ADFNode             `json:"content,omitempty" yaml:"content,omitempty"`
+	Text    string                 `json:"text,omitempty" yaml:"text,omitempty"`
+	Marks   []*ADFMark             `json:"marks,omitempty" yaml:"marks,omitempty"`
+}
+
+// ADFMark is a formatting mark of an ADF text node, like "strong" or "link".
+type ADFMark struct {
+	Type  string                 `json:"type" yaml:"type"`
+	Attrs map[string]interface{} `json:"attrs,omitempty" yaml:"attrs,omitempty"`
+}
+
+// NewADFDocument returns a document node with the content.
+func NewADFDocument(content ...*ADFNode) *ADFNode {
+	return &ADFNode{Type: "doc", Version: 1, Content: content}
+}
+
+// IsADFDocument returns true when the decoded JSON value is an ADF document.
+func IsADFDocument(value interface{}) bool {
+	doc, ok := value.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	_, hasContent := doc["content"]
+	return doc["type"] == "doc" && hasContent
+}
@@ -0,0 +1,49 @@
+package jiradata
+
+// The remote issue link schemas are not published with the platform schemas,
+// so these types are maintained by hand.
+
+// RemoteLink is a link from an issue to an object in a remote application,
+// ie a build or a pull request.  Links with a GlobalID are updated instead of
+// duplicated when posted again.
+type RemoteLink struct {
+	Application  *RemoteLinkApplication `json:"application,omitempty" yaml:"application,omitempty"`
+	GlobalID     string                 `json:"globalId,omitempty" yaml:"globalId,omitempty"`
+	ID           int                    `json:"id,omitempty" yaml:"id,omitempty"`
+	Object       *RemoteLinkObject      `json:"object,omitempty" yaml:"object,omitempty"`
+	Relationship string                 `json:"relationship,omitempty" yaml:"relationship,omitempty"`
+	Self         string                 `json:"self,omitempty" yaml:"self,omitempty"`
+}
+
+// RemoteLinks is a list of RemoteLink
+type RemoteLinks []*RemoteLink
+
+// RemoteLinkApplication identifies the application the remote object
+// belongs to
+type RemoteLinkApplication struct {
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+	Type string `json:"type,omitempty" yaml:"type,omitempty"`
+}
+
+// RemoteLinkObject is the remote object being linked to
+type RemoteLinkObject struct {
+	Icon    *RemoteLinkIcon   `json:"icon,omitempty" yaml:"icon,omitempty"`
+	Status  *RemoteLinkStatus `json:"status,omitempty" yaml:"status,omitempty"`
+	Summary string            `json:"summary,omitempty" yaml:"summary,omitempty"`
+	Title   string            `json:"title,omitempty" yaml:"title,omitempty"`
+	URL     string            `json:"url,omitempty" yaml:"url,omitempty"`
+}
+
+// RemoteLinkIcon is a 16x16 icon shown next to the remote object
+type RemoteLinkIcon struct {
+	Link     string `json:"link,omitempty" yaml:"link,omitempty"`
+	Title    string `json:"title,omitempty" yaml:"title,omitempty"`
+	URL16x16 string `json:"url16x16,omitempty" yaml:"url16x16,omitempty"`
+}
+
+// RemoteLinkStatus is the status of the remote object, resolved objects are
+// shown struck out.
+type RemoteLinkStatus struct {
+	Icon     *RemoteLinkIcon `json:"icon,omitempty" yaml:"icon,omitempty"`
+	Resolved bool            `json:"resolved,omitempty" yaml:"resolved,omitempty"`
+}
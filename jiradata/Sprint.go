@@ -0,0 +1,28 @@
+package jiradata
+
+// The Jira Software (agile) schemas are not published with the platform
+// schemas, so these types are maintained by hand.
+
+// Sprint is a sprint of a Jira Software board
+type Sprint struct {
+	CompleteDate  string `json:"completeDate,omitempty" yaml:"completeDate,omitempty"`
+	EndDate       string `json:"endDate,omitempty" yaml:"endDate,omitempty"`
+	Goal          string `json:"goal,omitempty" yaml:"goal,omitempty"`
+	ID            int    `json:"id,omitempty" yaml:"id,omitempty"`
+	Name          string `json:"name,omitempty" yaml:"name,omitempty"`
+	OriginBoardID int    `json:"originBoardId,omitempty" yaml:"originBoardId,omitempty"`
+	Self          string `json:"self,omitempty" yaml:"self,omitempty"`
+	StartDate     string `json:"startDate,omitempty" yaml:"startDate,omitempty"`
+	State         string `json:"state,omitempty" yaml:"state,omitempty"`
+}
+
+// Sprints is a list of Sprint
+type Sprints []*Sprint
+
+// SprintsWithPagination is a page of sprints for a board
+type SprintsWithPagination struct {
+	IsLast     bool    `json:"isLast,omitempty" yaml:"isLast,omitempty"`
+	MaxResults int     `json:"maxResults,omitempty" yaml:"maxResults,omitempty"`
+	StartAt    int     `json:"startAt,omitempty" yaml:"startAt,omitempty"`
+	Values     Sprints `json:"values,omitempty" yaml:"values,omitempty"`
+}
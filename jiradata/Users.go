@@ -0,0 +1,16 @@
+package jiradata
+
+// The paged group member results are not part of the published schemas, so
+// these types are maintained by hand.
+
+// Users is a list of User
+type Users []*User
+
+// UsersWithPagination is a page of the members of a group
+type UsersWithPagination struct {
+	IsLast     bool  `json:"isLast,omitempty" yaml:"isLast,omitempty"`
+	MaxResults int   `json:"maxResults,omitempty" yaml:"maxResults,omitempty"`
+	StartAt    int   `json:"startAt,omitempty" yaml:"startAt,omitempty"`
+	Total      int   `json:"total,omitempty" yaml:"total,omitempty"`
+	Values     Users `json:"values,omitempty" yaml:"values,omitempty"`
+}
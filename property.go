@@ -0,0 +1,99 @@
+package jira
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/go-jira/jira/jiradata"
+)
+
+// https://docs.atlassian.com/jira/REST/cloud/#api/2/issue/{issueIdOrKey}/properties-getPropertiesKeys
+func (j *Jira) GetIssuePropertyKeys(issue string) (*jiradata.Properties, error) {
+	return GetIssuePropertyKeys(j.UA, j.Endpoint, issue)
+}
+
+// GetIssuePropertyKeys returns the properties of the issue, only the Key of
+// each property is set.
+func GetIssuePropertyKeys(ua HttpClient, endpoint string, issue string) (*jiradata.Properties, error) {
+	uri := URLJoin(endpoint, "rest/api/2/issue", issue, "properties")
+	resp, err := ua.GetJSON(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 200 {
+		results := struct {
+			Keys jiradata.Properties `json:"keys,omitempty"`
+		}{
+			Keys: jiradata.Properties{},
+		}
+		return &results.Keys, json.NewDecoder(resp.Body).Decode(&results)
+	}
+	return nil, responseError(resp)
+}
+
+// https://docs.atlassian.com/jira/REST/cloud/#api/2/issue/{issueIdOrKey}/properties-getProperty
+func (j *Jira) GetIssueProperty(issue, key string) (*jiradata.EntityProperty, error) {
+	return GetIssueProperty(j.UA, j.Endpoint, issue, key)
+}
+
+func GetIssueProperty(ua HttpClient, endpoint string, issue, key string) (*jiradata.EntityProperty, error) {
+	uri := URLJoin(endpoint, "rest/api/2/issue", issue, "properties", key)
+	resp, err := ua.GetJSON(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 200 {
+		results := &jiradata.EntityProperty{}
+		return results, json.NewDecoder(resp.Body).Decode(results)
+	}
+	return nil, responseError(resp)
+}
+
+// https://docs.atlassian.com/jira/REST/cloud/#api/2/issue/{issueIdOrKey}/properties-setProperty
+func (j *Jira) SetIssueProperty(issue, key string, value interface{}) error {
+	return SetIssueProperty(j.UA, j.Endpoint, issue, key, value)
+}
+
+// SetIssueProperty creates or replaces the property, the value can be any
+// JSON value.
+func SetIssueProperty(ua HttpClient, endpoint string, issue, key string, value interface{}) error {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	uri := URLJoin(endpoint, "rest/api/2/issue", issue, "properties", key)
+	resp, err := ua.Put(uri, "application/json", bytes.NewBuffer(encoded))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// 201 when the property was created, 200 when it was updated
+	if resp.StatusCode == 200 || resp.StatusCode == 201 {
+		return nil
+	}
+	return responseError(resp)
+}
+
+// https://docs.atlassian.com/jira/REST/cloud/#api/2/issue/{issueIdOrKey}/properties-deleteProperty
+func (j *Jira) DeleteIssueProperty(issue, key string) error {
+	return DeleteIssueProperty(j.UA, j.Endpoint, issue, key)
+}
+
+func DeleteIssueProperty(ua HttpClient, endpoint string, issue, key string) error {
+	uri := URLJoin(endpoint, "rest/api/2/issue", issue, "properties", key)
+	resp, err := ua.Delete(uri)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 204 {
+		return nil
+	}
+	return responseError(resp)
+}
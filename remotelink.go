@@ -0,0 +1,96 @@
+package jira
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+
+	"github.com/go-jira/jira/jiradata"
+)
+
+type RemoteLinkProvider interface {
+	ProvideRemoteLink() *jiradata.RemoteLink
+}
+
+// https://docs.atlassian.com/jira/REST/cloud/#api/2/issue-getRemoteIssueLinks
+func (j *Jira) GetIssueRemoteLinks(issue string) (*jiradata.RemoteLinks, error) {
+	return GetIssueRemoteLinks(j.UA, j.Endpoint, issue)
+}
+
+func GetIssueRemoteLinks(ua HttpClient, endpoint string, issue string) (*jiradata.RemoteLinks, error) {
+	uri := URLJoin(endpoint, "rest/api/2/issue", issue, "remotelink")
+	resp, err := ua.GetJSON(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 200 {
+		results := jiradata.RemoteLinks{}
+		return &results, json.NewDecoder(resp.Body).Decode(&results)
+	}
+	return nil, responseError(resp)
+}
+
+// https://docs.atlassian.com/jira/REST/cloud/#api/2/issue-createOrUpdateRemoteIssueLink
+func (j *Jira) AddIssueRemoteLink(issue string, rlp RemoteLinkProvider) (*jiradata.RemoteLink, error) {
+	return AddIssueRemoteLink(j.UA, j.Endpoint, issue, rlp)
+}
+
+// AddIssueRemoteLink creates the remote link, or updates the existing remote
+// link with the same globalId.  The returned link only has the ID and Self
+// set.
+func AddIssueRemoteLink(ua HttpClient, endpoint string, issue string, rlp RemoteLinkProvider) (*jiradata.RemoteLink, error) {
+	req := rlp.ProvideRemoteLink()
+	encoded, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	uri := URLJoin(endpoint, "rest/api/2/issue", issue, "remotelink")
+	resp, err := ua.Post(uri, "application/json", bytes.NewBuffer(encoded))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// 201 when the link was created, 200 when it was updated
+	if resp.StatusCode == 200 || resp.StatusCode == 201 {
+		results := &jiradata.RemoteLink{}
+		return results, json.NewDecoder(resp.Body).Decode(results)
+	}
+	return nil, responseError(resp)
+}
+
+// https://docs.atlassian.com/jira/REST/cloud/#api/2/issue-deleteRemoteIssueLinkById
+func (j *Jira) DeleteIssueRemoteLink(issue, id string) error {
+	return DeleteIssueRemoteLink(j.UA, j.Endpoint, issue, id)
+}
+
+func DeleteIssueRemoteLink(ua HttpClient, endpoint string, issue, id string) error {
+	uri := URLJoin(endpoint, "rest/api/2/issue", issue, "remotelink", id)
+	return deleteRemoteLink(ua, uri)
+}
+
+// https://docs.atlassian.com/jira/REST/cloud/#api/2/issue-deleteRemoteIssueLinkByGlobalId
+func (j *Jira) DeleteIssueRemoteLinkByGlobalID(issue, globalID string) error {
+	return DeleteIssueRemoteLinkByGlobalID(j.UA, j.Endpoint, issue, globalID)
+}
+
+func DeleteIssueRemoteLinkByGlobalID(ua HttpClient, endpoint string, issue, globalID string) error {
+	uri := URLJoin(endpoint, "rest/api/2/issue", issue, "remotelink")
+	uri += "?globalId=" + url.QueryEscape(globalID)
+	return deleteRemoteLink(ua, uri)
+}
+
+func deleteRemoteLink(ua HttpClient, uri string) error {
+	resp, err := ua.Delete(uri)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 204 {
+		return nil
+	}
+	return responseError(resp)
+}
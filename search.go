@@ -79,6 +79,7 @@ func (j *Jira) Search(sp SearchProvider, opts ...SearchOpt) (*jiradata.SearchRes
 
 type searchConfig struct {
 	autoPaginate bool
+	pageHandler  func(jiradata.Issues) error
 }
 
 type SearchOpt func(*searchConfig)
@@ -89,6 +90,16 @@ func WithAutoPagination() SearchOpt {
 	}
 }
 
+// WithPageHandler will pass the issues of each page to the handler as the
+// pages are fetched, rather than returning all the issues at once, so large
+// result sets can be streamed.  It implies WithAutoPagination.
+func WithPageHandler(handler func(jiradata.Issues) error) SearchOpt {
+	return func(c *searchConfig) {
+		c.autoPaginate = true
+		c.pageHandler = handler
+	}
+}
+
 func Search(ua HttpClient, endpoint string, sp SearchProvider, opts ...SearchOpt) (*jiradata.SearchResults, error) {
 	c := &searchConfig{}
 	for _, opt := range opts {
@@ -103,6 +114,7 @@ func Search(ua HttpClient, endpoint string, sp SearchProvider, opts ...SearchOpt
 	}
 
 	issues := jiradata.Issues{}
+	fetched := 0
 	for {
 		encoded, err := json.Marshal(req)
 		if err != nil {
@@ -128,16 +140,23 @@ func Search(ua HttpClient, endpoint string, sp SearchProvider, opts ...SearchOpt
 			return page, nil
 		}
 
-		issues = append(issues, page.Issues...)
+		fetched += len(page.Issues)
+		if c.pageHandler != nil {
+			if err := c.pageHandler(page.Issues); err != nil {
+				return nil, err
+			}
+		} else {
+			issues = append(issues, page.Issues...)
+		}
 		// if we are done paginating just force all issues onto current
 		// response and return
-		if (limit > 0 && len(issues) >= limit) || len(issues) >= page.Total {
+		if (limit > 0 && fetched >= limit) || fetched >= page.Total || len(page.Issues) == 0 {
 			page.Issues = issues
 			return page, nil
 		}
-		req.StartAt = len(issues)
-		if len(issues)+req.MaxResults > limit {
-			req.MaxResults = limit - len(issues)
+		req.StartAt = fetched
+		if limit > 0 && fetched+req.MaxResults > limit {
+			req.MaxResults = limit - fetched
 		}
 	}
 }
@@ -0,0 +1,66 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/go-jira/jira/jiradata"
+)
+
+// https://docs.atlassian.com/jira-software/REST/latest/#agile/1.0/board/{boardId}/sprint-getAllSprints
+func (j *Jira) GetBoardSprints(board, state string) (*jiradata.Sprints, error) {
+	return GetBoardSprints(j.UA, j.Endpoint, board, state)
+}
+
+// GetBoardSprints returns the sprints of the board.  state is a comma
+// separated list of "future", "active" and "closed", or empty for all sprints.
+func GetBoardSprints(ua HttpClient, endpoint string, board, state string) (*jiradata.Sprints, error) {
+	startAt := 0
+	sprints := jiradata.Sprints{}
+	for {
+		uri := URLJoin(endpoint, "rest/agile/1.0/board", board, "sprint")
+		uri += fmt.Sprintf("?startAt=%d", startAt)
+		if state != "" {
+			uri += "&state=" + url.QueryEscape(state)
+		}
+		resp, err := ua.GetJSON(uri)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != 200 {
+			return nil, responseError(resp)
+		}
+		results := &jiradata.SprintsWithPagination{}
+		if err := json.NewDecoder(resp.Body).Decode(results); err != nil {
+			return nil, err
+		}
+		sprints = append(sprints, results.Values...)
+		if results.IsLast || len(results.Values) == 0 {
+			return &sprints, nil
+		}
+		startAt += len(results.Values)
+	}
+}
+
+// https://docs.atlassian.com/jira-software/REST/latest/#agile/1.0/sprint-getSprint
+func (j *Jira) GetSprint(sprint string) (*jiradata.Sprint, error) {
+	return GetSprint(j.UA, j.Endpoint, sprint)
+}
+
+func GetSprint(ua HttpClient, endpoint string, sprint string) (*jiradata.Sprint, error) {
+	uri := URLJoin(endpoint, "rest/agile/1.0/sprint", sprint)
+	resp, err := ua.GetJSON(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 200 {
+		results := &jiradata.Sprint{}
+		return results, json.NewDecoder(resp.Body).Decode(results)
+	}
+	return nil, responseError(resp)
+}
@@ -0,0 +1,26 @@
+package jira
+
+import (
+	"encoding/json"
+
+	"github.com/go-jira/jira/jiradata"
+)
+
+// https://docs.atlassian.com/jira/REST/cloud/#api/2/status-getStatuses
+func (j *Jira) GetStatuses() ([]jiradata.Status, error) {
+	return GetStatuses(j.UA, j.Endpoint)
+}
+
+func GetStatuses(ua HttpClient, endpoint string) ([]jiradata.Status, error) {
+	uri := URLJoin(endpoint, "rest/api/2/status")
+	resp, err := ua.GetJSON(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == 200 {
+		results := []jiradata.Status{}
+		return results, json.NewDecoder(resp.Body).Decode(&results)
+	}
+	return nil, responseError(resp)
+}
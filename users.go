@@ -19,6 +19,9 @@ type UserSearchOptions struct {
 }
 
 // https://developer.atlassian.com/cloud/jira/platform/rest/v2/#api-rest-api-2-user-search-get
+func (j *Jira) UserSearch(opts *UserSearchOptions) ([]*jiradata.User, error) {
+	return UserSearch(j.UA, j.Endpoint, opts)
+}
 
 func UserSearch(ua HttpClient, endpoint string, opts *UserSearchOptions) ([]*jiradata.User, error) {
 	uri := URLJoin(endpoint, "rest/api/2/user/search")
@@ -26,6 +29,9 @@ func UserSearch(ua HttpClient, endpoint string, opts *UserSearchOptions) ([]*jir
 	if opts.Query != "" {
 		params = append(params, "query="+url.QueryEscape(opts.Query))
 	}
+	if opts.Username != "" {
+		params = append(params, "username="+url.QueryEscape(opts.Username))
+	}
 	if opts.AccountID != "" {
 		params = append(params, "accountId="+url.QueryEscape(opts.AccountID))
 	}
@@ -53,3 +59,44 @@ func UserSearch(ua HttpClient, endpoint string, opts *UserSearchOptions) ([]*jir
 	}
 	return nil, responseError(resp)
 }
+
+type UserOptions struct {
+	Username  string `yaml:"username,omitempty" json:"username,omitempty"`
+	AccountID string `yaml:"accountId,omitempty" json:"accountId,omitempty"`
+	Expand    string `yaml:"expand,omitempty" json:"expand,omitempty"`
+}
+
+// https://developer.atlassian.com/cloud/jira/platform/rest/v2/#api-rest-api-2-user-get
+func (j *Jira) GetUser(opts *UserOptions) (*jiradata.User, error) {
+	return GetUser(j.UA, j.Endpoint, opts)
+}
+
+// GetUser returns the user with the account id on Jira Cloud, or with the
+// username on Jira Server.
+func GetUser(ua HttpClient, endpoint string, opts *UserOptions) (*jiradata.User, error) {
+	uri := URLJoin(endpoint, "rest/api/2/user")
+	params := []string{}
+	if opts.AccountID != "" {
+		params = append(params, "accountId="+url.QueryEscape(opts.AccountID))
+	}
+	if opts.Username != "" {
+		params = append(params, "username="+url.QueryEscape(opts.Username))
+	}
+	if opts.Expand != "" {
+		params = append(params, "expand="+url.QueryEscape(opts.Expand))
+	}
+	if len(params) > 0 {
+		uri += "?" + strings.Join(params, "&")
+	}
+	resp, err := ua.GetJSON(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 200 {
+		results := &jiradata.User{}
+		return results, json.NewDecoder(resp.Body).Decode(results)
+	}
+	return nil, responseError(resp)
+}